@@ -0,0 +1,101 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+var (
+	// ErrAlphabetLength is returned when an alphabet does not have exactly 64 characters
+	ErrAlphabetLength = errors.New("alphabet must have 64 characters")
+	// ErrAlphabetDuplicate is returned when an alphabet contains a character more than once
+	ErrAlphabetDuplicate = errors.New("alphabet contains a duplicate character")
+	// ErrAlphabetReserved is returned when an alphabet contains a character that is not unreserved in urls
+	ErrAlphabetReserved = errors.New("alphabet contains a character that is reserved in urls")
+)
+
+// CustomAlphabet is a validated 64 character alphabet with its precomputed reverse lookup
+type CustomAlphabet struct {
+	digits [64]byte
+	table  *lookup.Table
+}
+
+// NewAlphabet creates a custom alphabet from a string of 64 unique characters.
+// Only characters that are unreserved in urls (RFC 3986) are allowed: A-Z, a-z, 0-9, '-', '.', '_' and '~'.
+// This means that encoded IDs never have to be escaped in a url.
+// Returns an error if the alphabet does not have 64 characters, contains a duplicate, or a reserved character.
+func NewAlphabet(s string) (*CustomAlphabet, error) {
+	if len(s) != 64 {
+		return nil, fmt.Errorf("%w: got %d characters", ErrAlphabetLength, len(s))
+	}
+	a := &CustomAlphabet{}
+	copy(a.digits[:], s)
+	for i, c := range a.digits {
+		if !isUnreserved(c) {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrAlphabetReserved, c, i)
+		}
+	}
+	a.table = lookup.New(a.digits[:])
+	for i, c := range a.digits {
+		if int(a.table[c]) != i {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrAlphabetDuplicate, c, i)
+		}
+	}
+	return a, nil
+}
+
+// isUnreserved returns true if c is an unreserved character in urls as defined in RFC 3986
+func isUnreserved(c byte) bool {
+	switch {
+	case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+		return true
+	case c == '-', c == '.', c == '_', c == '~':
+		return true
+	}
+	return false
+}
+
+// String returns the characters of the alphabet
+func (a *CustomAlphabet) String() string {
+	return string(a.digits[:])
+}
+
+// Digits returns the characters of the alphabet, the method value can be used as an Alphabet
+func (a *CustomAlphabet) Digits() [64]byte {
+	return a.digits
+}
+
+// Lookup returns the reverse lookup of the alphabet, the method value can be used as an AlphabetLookup.
+// A new map is built on each call, use a codec created with NewBase64Codec or NewInflux64Codec to avoid this.
+func (a *CustomAlphabet) Lookup() map[byte]uint64 {
+	m := make(map[byte]uint64, len(a.digits))
+	for i, c := range a.digits {
+		m[c] = uint64(i)
+	}
+	return m
+}
+
+// SortPreserving returns true if the characters of the alphabet are in ascending byte order.
+// Only then do Influx64 strings sort in the same order as the IDs they encode. The base64 encoding never preserves the
+// sort order, because it encodes the least significant byte first.
+func (a *CustomAlphabet) SortPreserving() bool {
+	for i := 1; i < len(a.digits); i++ {
+		if a.digits[i-1] >= a.digits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewBase64Codec returns a base64 codec with a custom alphabet, the codec can be registered with RegisterCodec
+func NewBase64Codec(name string, a *CustomAlphabet) Codec {
+	return base64Codec{name: name, digits: &a.digits, table: a.table}
+}
+
+// NewInflux64Codec returns an Influx style base64 codec with a custom alphabet, the codec can be registered with
+// RegisterCodec
+func NewInflux64Codec(name string, a *CustomAlphabet) Codec {
+	return influx64Codec{name: name, digits: &a.digits, table: a.table}
+}
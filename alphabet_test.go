@@ -0,0 +1,153 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	influxAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz~"
+	urlAlphabet    = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+// TestNewAlphabet_Errors tests the validation of custom alphabets
+func TestNewAlphabet_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		want     error
+	}{
+		{name: "influx", alphabet: influxAlphabet},
+		{name: "url", alphabet: urlAlphabet},
+		{name: "too short", alphabet: influxAlphabet[1:], want: ErrAlphabetLength},
+		{name: "too long", alphabet: influxAlphabet + ".", want: ErrAlphabetLength},
+		{name: "empty", alphabet: "", want: ErrAlphabetLength},
+		{name: "duplicate", alphabet: "00" + influxAlphabet[2:], want: ErrAlphabetDuplicate},
+		{name: "slash", alphabet: "/" + influxAlphabet[1:], want: ErrAlphabetReserved},
+		{name: "plus", alphabet: influxAlphabet[:63] + "+", want: ErrAlphabetReserved},
+		{name: "percent", alphabet: "%" + influxAlphabet[1:], want: ErrAlphabetReserved},
+		{name: "non ascii", alphabet: "\xff" + influxAlphabet[1:], want: ErrAlphabetReserved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAlphabet(tt.alphabet)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if err == nil && a.String() != tt.alphabet {
+				t.Errorf("String() = %v, want %v", a.String(), tt.alphabet)
+			}
+		})
+	}
+}
+
+// TestCustomAlphabet_SortPreserving tests the detection of sort preserving alphabets
+func TestCustomAlphabet_SortPreserving(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		want     bool
+	}{
+		{name: "influx", alphabet: influxAlphabet, want: true},
+		{name: "url", alphabet: urlAlphabet, want: false},
+		{name: "swapped", alphabet: "10" + influxAlphabet[2:], want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAlphabet(tt.alphabet)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := a.SortPreserving(); got != tt.want {
+				t.Errorf("SortPreserving() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCustomAlphabet_SortOrder tests that Influx64 strings with a sort preserving alphabet sort like their IDs
+func TestCustomAlphabet_SortOrder(t *testing.T) {
+	a, err := NewAlphabet(influxAlphabet)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	c := NewInflux64Codec("sorted", a)
+	r := rand.New(rand.NewSource(1))
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i] = ID(r.Uint64())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.FormatWith(c)
+	}
+	if !sort.StringsAreSorted(strs) {
+		t.Errorf("expected encoded IDs to be sorted")
+	}
+}
+
+// TestCustomAlphabet_Codecs tests that a custom alphabet works with the base64 and Influx64 functions and codecs
+func TestCustomAlphabet_Codecs(t *testing.T) {
+	a, err := NewAlphabet(urlAlphabet)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	base64Codec := NewBase64Codec("custom64", a)
+	influx64Codec := NewInflux64Codec("customInflux64", a)
+	if base64Codec.Name() != "custom64" || influx64Codec.Name() != "customInflux64" {
+		t.Errorf("unexpected codec names %v and %v", base64Codec.Name(), influx64Codec.Name())
+	}
+	for _, id := range []ID{0, 1, 0xA000B00F0A023452, math.MaxUint64} {
+		s := id.Base64StringCustom(a.Digits)
+		if s != URLBase64ID(id).String() {
+			t.Errorf("Base64StringCustom() = %v, want %v", s, URLBase64ID(id).String())
+		}
+		if got := id.FormatWith(base64Codec); got != s {
+			t.Errorf("FormatWith() = %v, want %v", got, s)
+		}
+		if got, err := ParseBase64StringCustom(s, a.Lookup); err != nil || got != id {
+			t.Errorf("ParseBase64StringCustom() = %v, %v, want %v", uint64(got), err, uint64(id))
+		}
+		if got, err := Parse(base64Codec, s); err != nil || got != id {
+			t.Errorf("Parse() = %v, %v, want %v", uint64(got), err, uint64(id))
+		}
+
+		s = id.Influx64StringCustom(a.Digits)
+		if got := id.FormatWith(influx64Codec); got != s {
+			t.Errorf("FormatWith() = %v, want %v", got, s)
+		}
+		if got := IDFromInflux64StringCustom(s, a.Lookup); got != id {
+			t.Errorf("IDFromInflux64StringCustom() = %v, want %v", uint64(got), uint64(id))
+		}
+		if got, err := Parse(influx64Codec, s); err != nil || got != id {
+			t.Errorf("Parse() = %v, %v, want %v", uint64(got), err, uint64(id))
+		}
+	}
+	if _, err := Parse(influx64Codec, strings.Repeat("+", 11)); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected %v, got %v", ErrInvalidCharacter, err)
+	}
+}
+
+// ExampleNewAlphabet is an example of creating a validated custom alphabet
+func ExampleNewAlphabet() {
+	a, err := NewAlphabet("-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(a.SortPreserving())
+	c := NewInflux64Codec("sortable", a)
+	fmt.Println(ID(1).FormatWith(c))
+
+	_, err = NewAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/")
+	fmt.Println(err)
+	// Output:
+	// true
+	// ----------0
+	// alphabet contains a character that is reserved in urls: '+' at position 62
+}
@@ -0,0 +1,57 @@
+package snowflake
+
+import "encoding/binary"
+
+// MarshalBinary implements encoding.BinaryMarshaler, the ID is marshalled as 8 bytes in big-endian order.
+// Big-endian order makes byte-wise comparison of marshalled IDs match the numeric order of the IDs.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), uint64(id)), nil
+}
+
+// AppendBinary appends the 8 byte big-endian representation of the ID to b and returns the extended buffer
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(b, uint64(id)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, data must be 8 bytes in big-endian order
+func (id *ID) UnmarshalBinary(data []byte) error {
+	v, err := IDFromBytes(data)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// IDFromBytes returns a snowflake ID from 8 bytes in big-endian order, returning an error if b is not 8 bytes long
+func IDFromBytes(b []byte) (ID, error) {
+	if len(b) != 8 {
+		return 0, &LengthError{Length: len(b), Expected: 8}
+	}
+	return ID(binary.BigEndian.Uint64(b)), nil
+}
+
+// Key returns an ordered composite key of the prefix followed by the big-endian representation of the ID.
+// Byte-wise comparison of keys with the same prefix matches the order of the IDs, which makes them suitable as keys
+// for ordered key-value stores. Keys with different prefixes are ordered by prefix first, as long as no prefix is a
+// proper prefix of another, for example because all prefixes have the same length or end with a separator.
+func Key(prefix []byte, id ID) []byte {
+	return AppendKey(make([]byte, 0, len(prefix)+8), prefix, id)
+}
+
+// AppendKey appends the ordered composite key of the prefix and the ID to dst and returns the extended buffer, see Key
+func AppendKey(dst []byte, prefix []byte, id ID) []byte {
+	dst = append(dst, prefix...)
+	return binary.BigEndian.AppendUint64(dst, uint64(id))
+}
+
+// SplitKey splits a composite key created by Key into its prefix and ID.
+// The prefix shares the underlying array of key.
+// Returns an error if the key is shorter than 8 bytes.
+func SplitKey(key []byte) ([]byte, ID, error) {
+	if len(key) < 8 {
+		return nil, 0, &LengthError{Length: len(key), Expected: 8}
+	}
+	n := len(key) - 8
+	return key[:n:n], ID(binary.BigEndian.Uint64(key[n:])), nil
+}
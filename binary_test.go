@@ -0,0 +1,109 @@
+package snowflake
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = ID(0)
+	_ encoding.BinaryUnmarshaler = (*ID)(nil)
+)
+
+// TestID_MarshalBinary tests the big-endian binary marshalling of the ID
+func TestID_MarshalBinary(t *testing.T) {
+	b, err := ID(0xA000B00F0A023452).MarshalBinary()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []byte{0xA0, 0x00, 0xB0, 0x0F, 0x0A, 0x02, 0x34, 0x52}
+	if !bytes.Equal(b, want) {
+		t.Errorf("got %x, want %x", b, want)
+	}
+
+	var id ID
+	if err = id.UnmarshalBinary(b); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != 0xA000B00F0A023452 {
+		t.Errorf("got %x, want %x", uint64(id), uint64(0xA000B00F0A023452))
+	}
+
+	if err = id.UnmarshalBinary(b[:7]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+}
+
+// TestID_AppendBinary tests appending the binary representation to an existing buffer
+func TestID_AppendBinary(t *testing.T) {
+	b, err := ID(math.MaxUint64).AppendBinary([]byte{1, 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []byte{1, 2, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if !bytes.Equal(b, want) {
+		t.Errorf("got %x, want %x", b, want)
+	}
+}
+
+// TestIDFromBytes tests creating an ID from bytes
+func TestIDFromBytes(t *testing.T) {
+	id, err := IDFromBytes([]byte{0, 0, 0, 0, 0, 0, 0, 1})
+	if err != nil || id != 1 {
+		t.Errorf("expected 1 without error, got %v, %v", uint64(id), err)
+	}
+	if _, err = IDFromBytes(nil); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+	if _, err = IDFromBytes(make([]byte, 9)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+}
+
+// TestKey_Order tests that byte-wise ordering of keys matches the ordering of IDs
+func TestKey_Order(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ids := []ID{0, 1, 0xFF, 0x100, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, ID(r.Uint64()))
+	}
+	keys := make([][]byte, len(ids))
+	for i, id := range ids {
+		keys[i] = Key([]byte("user/"), id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	for i, key := range keys {
+		prefix, id, err := SplitKey(key)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if string(prefix) != "user/" {
+			t.Errorf("got prefix %q, want %q", prefix, "user/")
+		}
+		if id != ids[i] {
+			t.Errorf("key %d: got %v, want %v", i, uint64(id), uint64(ids[i]))
+		}
+	}
+}
+
+// TestAppendKey tests appending a key to an existing buffer and splitting invalid keys
+func TestAppendKey(t *testing.T) {
+	key := AppendKey([]byte("db:"), []byte("t/"), 1)
+	want := []byte{'d', 'b', ':', 't', '/', 0, 0, 0, 0, 0, 0, 0, 1}
+	if !bytes.Equal(key, want) {
+		t.Errorf("got %x, want %x", key, want)
+	}
+	prefix, id, err := SplitKey(Key(nil, 2))
+	if err != nil || len(prefix) != 0 || id != 2 {
+		t.Errorf("expected empty prefix and 2, got %q, %v, %v", prefix, uint64(id), err)
+	}
+	if _, _, err = SplitKey([]byte{1, 2, 3}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+}
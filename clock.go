@@ -0,0 +1,79 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInvalidSlew is returned when the slew rate of a monotonic clock is not at least 0 and below 1
+var ErrInvalidSlew = errors.New("slew rate is not at least 0 and below 1")
+
+// MonotonicClock is a time source that reads the wall clock once and then advances with the monotonic clock, so steps
+// of the wall clock, for example by NTP, do not reach the generator. Its Now method is a TimeFunc.
+//
+// Without slewing the clock drifts from the wall clock over time. With a slew rate it runs up to that fraction faster
+// or slower until it follows the wall clock again, it never runs backwards.
+type MonotonicClock struct {
+	start     int64
+	slew      float64
+	monotonic func() time.Duration
+	wall      func() int64
+
+	mu      sync.Mutex
+	elapsed time.Duration
+	current int64
+}
+
+// NewMonotonicClock returns a monotonic clock anchored at the current wall time.
+// slew is the largest fraction by which the clock corrects towards the wall clock, 0.0005 corrects 0.5 milliseconds
+// per second like ntpd. A slew of 0 never corrects.
+// Returns ErrInvalidSlew if the slew is not at least 0 and below 1.
+func NewMonotonicClock(slew float64) (*MonotonicClock, error) {
+	if slew < 0 || slew >= 1 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSlew, slew)
+	}
+	anchor := time.Now()
+	return newMonotonicClock(anchor.UnixNano(), slew, func() time.Duration {
+		return time.Since(anchor)
+	}, func() int64 {
+		return time.Now().UnixNano()
+	}), nil
+}
+
+func newMonotonicClock(start int64, slew float64, monotonic func() time.Duration, wall func() int64) *MonotonicClock {
+	return &MonotonicClock{
+		start:     start,
+		slew:      slew,
+		monotonic: monotonic,
+		wall:      wall,
+		current:   start,
+	}
+}
+
+// Now returns the time of the clock in milliseconds since the Unix epoch
+func (c *MonotonicClock) Now() uint64 {
+	if c.slew == 0 {
+		return uint64((c.start + int64(c.monotonic())) / int64(time.Millisecond))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elapsed := c.monotonic()
+	delta := int64(elapsed - c.elapsed)
+	if delta <= 0 {
+		return uint64(c.current / int64(time.Millisecond))
+	}
+	c.elapsed = elapsed
+	// The correction is smaller than the elapsed time, so the clock does not run backwards
+	correction := c.wall() - c.current - delta
+	limit := int64(float64(delta) * c.slew)
+	if correction > limit {
+		correction = limit
+	} else if correction < -limit {
+		correction = -limit
+	}
+	c.current += delta + correction
+	return uint64(c.current / int64(time.Millisecond))
+}
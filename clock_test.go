@@ -0,0 +1,81 @@
+package snowflake
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestMonotonicClock tests that the clock follows the monotonic clock and slews towards the wall clock
+func TestMonotonicClock(t *testing.T) {
+	tests := []struct {
+		name string
+		slew float64
+		// step moves the wall clock once, after the first read
+		step time.Duration
+		want []uint64
+	}{
+		{name: "no slew ignores a step forward", step: time.Second, want: []uint64{1000, 2000, 3000, 4000}},
+		{name: "no slew ignores a step backward", step: -time.Second, want: []uint64{1000, 2000, 3000, 4000}},
+		{name: "slew catches up", slew: 0.5, step: time.Second, want: []uint64{1000, 2500, 4000, 5000}},
+		{name: "slew slows down", slew: 0.5, step: -time.Second, want: []uint64{1000, 1500, 2000, 3000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var elapsed, wall time.Duration
+			clock := newMonotonicClock(0, tt.slew, func() time.Duration {
+				return elapsed
+			}, func() int64 {
+				return int64(wall)
+			})
+			for i, want := range tt.want {
+				elapsed += time.Second
+				wall += time.Second
+				if i == 1 {
+					wall += tt.step
+				}
+				if got := clock.Now(); got != want {
+					t.Errorf("read %d: expected %v, got %v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+// TestNewMonotonicClock tests that the clock starts at the wall time and rejects invalid slew rates
+func TestNewMonotonicClock(t *testing.T) {
+	clock, err := NewMonotonicClock(0.0005)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if diff := int64(clock.Now()) - time.Now().UnixMilli(); diff < -1 || diff > 1 {
+		t.Errorf("expected the clock to follow the wall clock, it differs %vms", diff)
+	}
+
+	for _, slew := range []float64{-0.1, 1, 2} {
+		if _, err = NewMonotonicClock(slew); !errors.Is(err, ErrInvalidSlew) {
+			t.Errorf("expected ErrInvalidSlew for %v, got %v", slew, err)
+		}
+		if _, err = NewGenerator(1, WithMonotonicClock(slew)); !errors.Is(err, ErrInvalidSlew) {
+			t.Errorf("expected ErrInvalidSlew from WithMonotonicClock for %v, got %v", slew, err)
+		}
+	}
+}
+
+// TestWithMonotonicClock tests that a generator with a monotonic clock generates IDs at the current time
+func TestWithMonotonicClock(t *testing.T) {
+	generator, err := NewGenerator(1, WithMonotonicClock(0))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	id, err := generator.NextID()
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if diff := time.Since(generator.DecodeID(id).Time); diff < 0 || diff > time.Second {
+		t.Errorf("expected the ID to have the current time, it differs %v", diff)
+	}
+}
@@ -0,0 +1,309 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/base64"
+	"github.com/crosscode-nl/snowflake/internal/codecs/base64/influx"
+	"github.com/crosscode-nl/snowflake/internal/codecs/hex"
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+// ErrUnknownCodec is returned when no codec is registered with the requested name
+var ErrUnknownCodec = errors.New("unknown codec")
+
+// Codec encodes 64-bit numbers into text and decodes them back
+type Codec interface {
+	// Name returns the name of the codec, which is used to select it from the registry
+	Name() string
+	// EncodedLen returns the maximum number of bytes Encode appends
+	EncodedLen() int
+	// Encode appends the encoding of n to dst and returns the extended buffer
+	Encode(dst []byte, n uint64) []byte
+	// Decode decodes src into a number, returning an error if src is invalid
+	Decode(src []byte) (uint64, error)
+}
+
+// The built-in codecs, they are registered under their names
+var (
+	// LowerHex encodes into a 16 character lower case hex string, it is registered as "lowerhex"
+	LowerHex Codec = hexCodec{name: "lowerhex", digits: &hex.LowerDigits, table: hex.LowerTable}
+	// UpperHex encodes into a 16 character upper case hex string, it is registered as "upperhex"
+	UpperHex Codec = hexCodec{name: "upperhex", digits: &hex.UpperDigits, table: hex.UpperTable}
+	// Base64 encodes into an 11 character base64 string, it is registered as "base64"
+	Base64 Codec = base64Codec{name: "base64", digits: &base64.StdDigits, table: base64.StdTable}
+	// Base64URL encodes into an 11 character base64 string with the url alphabet, it is registered as "base64url"
+	Base64URL Codec = base64Codec{name: "base64url", digits: &base64.UrlDigits, table: base64.UrlTable}
+	// Base64Mime encodes into an 11 character base64 string with the mime alphabet, it is registered as "base64mime"
+	Base64Mime Codec = base64Codec{name: "base64mime", digits: &base64.MimeDigits, table: base64.MimeTable}
+	// Influx64 encodes into an 11 character Influx style base64 string, it is registered as "influx64"
+	Influx64 Codec = influx64Codec{name: "influx64", digits: &influx.Digits, table: influx.Table}
+	// Crockford encodes into a 13 character Crockford base32 string, it is registered as "crockford"
+	Crockford Codec = crockfordCodec{}
+	// Base58 encodes into an 11 character padded base58 string, it is registered as "base58"
+	Base58 Codec = base58Codec{name: "base58"}
+	// Base58Short encodes into the shortest base58 string, it is registered as "base58short"
+	Base58Short Codec = base58Codec{name: "base58short", short: true}
+	// Base62 encodes into an 11 character padded base62 string, it is registered as "base62"
+	Base62 Codec = base62Codec{name: "base62"}
+	// Base62Short encodes into the shortest base62 string, it is registered as "base62short"
+	Base62Short Codec = base62Codec{name: "base62short", short: true}
+	// Decimal encodes into a decimal string without leading zeros, it is registered as "decimal"
+	Decimal Codec = decimalCodec{name: "decimal"}
+	// PaddedDecimal encodes into a 20 character zero padded decimal string, it is registered as "decimal20"
+	PaddedDecimal Codec = decimalCodec{name: "decimal20", padded: true}
+)
+
+var registry = struct {
+	sync.RWMutex
+	codecs map[string]Codec
+}{
+	codecs: map[string]Codec{},
+}
+
+func init() {
+	for _, c := range []Codec{LowerHex, UpperHex, Base64, Base64URL, Base64Mime, Influx64, Crockford,
+		Base58, Base58Short, Base62, Base62Short, Decimal, PaddedDecimal} {
+		RegisterCodec(c)
+	}
+}
+
+// RegisterCodec makes a codec available by its name.
+// It panics if the codec is nil or if a codec with the same name is already registered.
+func RegisterCodec(c Codec) {
+	if c == nil {
+		panic("snowflake: RegisterCodec codec is nil")
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.codecs[c.Name()]; dup {
+		panic("snowflake: RegisterCodec called twice for codec " + c.Name())
+	}
+	registry.codecs[c.Name()] = c
+}
+
+// CodecByName returns the registered codec with the given name, returning an error if there is none
+func CodecByName(name string) (Codec, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	c, ok := registry.codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCodec, name)
+	}
+	return c, nil
+}
+
+// CodecNames returns the sorted names of the registered codecs
+func CodecNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.codecs))
+	for name := range registry.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatWith returns the snowflake ID encoded by the codec
+func (id ID) FormatWith(c Codec) string {
+	return string(c.Encode(make([]byte, 0, c.EncodedLen()), uint64(id)))
+}
+
+// AppendFormat appends the snowflake ID encoded by the codec to dst and returns the extended buffer
+func (id ID) AppendFormat(dst []byte, c Codec) []byte {
+	return c.Encode(dst, uint64(id))
+}
+
+// Parse parses a snowflake ID from a string with the codec, returning an error if the string is invalid
+func Parse(c Codec, s string) (ID, error) {
+	n, err := c.Decode([]byte(s))
+	return ID(n), err
+}
+
+type hexCodec struct {
+	name   string
+	digits *hex.Digits
+	table  *lookup.Table
+}
+
+func (c hexCodec) Name() string {
+	return c.name
+}
+
+func (c hexCodec) EncodedLen() int {
+	return 16
+}
+
+func (c hexCodec) Encode(dst []byte, n uint64) []byte {
+	var b [16]byte
+	hex.Encode(&b, n, c.digits)
+	return append(dst, b[:]...)
+}
+
+func (c hexCodec) Decode(src []byte) (uint64, error) {
+	var b [16]byte
+	if len(src) != len(b) {
+		return 0, &LengthError{Length: len(src), Expected: len(b)}
+	}
+	copy(b[:], src)
+	id, err := parseHex(&b, c.table)
+	return uint64(id), err
+}
+
+type base64Codec struct {
+	name   string
+	digits *[64]byte
+	table  *lookup.Table
+}
+
+func (c base64Codec) Name() string {
+	return c.name
+}
+
+func (c base64Codec) EncodedLen() int {
+	return 11
+}
+
+func (c base64Codec) Encode(dst []byte, n uint64) []byte {
+	var b [11]byte
+	base64.Encode(&b, n, c.digits)
+	return append(dst, b[:]...)
+}
+
+func (c base64Codec) Decode(src []byte) (uint64, error) {
+	var b [11]byte
+	if len(src) != len(b) {
+		return 0, &LengthError{Length: len(src), Expected: len(b)}
+	}
+	copy(b[:], src)
+	id, err := parseBase64(&b, c.table)
+	return uint64(id), err
+}
+
+type influx64Codec struct {
+	name   string
+	digits *[64]byte
+	table  *lookup.Table
+}
+
+func (c influx64Codec) Name() string {
+	return c.name
+}
+
+func (c influx64Codec) EncodedLen() int {
+	return 11
+}
+
+func (c influx64Codec) Encode(dst []byte, n uint64) []byte {
+	var b [11]byte
+	influx.Encode(&b, n, c.digits)
+	return append(dst, b[:]...)
+}
+
+func (c influx64Codec) Decode(src []byte) (uint64, error) {
+	var b [11]byte
+	if len(src) != len(b) {
+		return 0, &LengthError{Length: len(src), Expected: len(b)}
+	}
+	copy(b[:], src)
+	id, err := parseInflux64(&b, c.table)
+	return uint64(id), err
+}
+
+type crockfordCodec struct{}
+
+func (crockfordCodec) Name() string {
+	return "crockford"
+}
+
+func (crockfordCodec) EncodedLen() int {
+	return 13
+}
+
+func (crockfordCodec) Encode(dst []byte, n uint64) []byte {
+	return ID(n).AppendCrockford(dst)
+}
+
+func (crockfordCodec) Decode(src []byte) (uint64, error) {
+	id, err := ParseCrockfordBytes(src)
+	return uint64(id), err
+}
+
+type base58Codec struct {
+	name  string
+	short bool
+}
+
+func (c base58Codec) Name() string {
+	return c.name
+}
+
+func (base58Codec) EncodedLen() int {
+	return 11
+}
+
+func (c base58Codec) Encode(dst []byte, n uint64) []byte {
+	if c.short {
+		return ID(n).AppendBase58Short(dst)
+	}
+	return ID(n).AppendBase58(dst)
+}
+
+func (base58Codec) Decode(src []byte) (uint64, error) {
+	id, err := ParseBase58Bytes(src)
+	return uint64(id), err
+}
+
+type base62Codec struct {
+	name  string
+	short bool
+}
+
+func (c base62Codec) Name() string {
+	return c.name
+}
+
+func (base62Codec) EncodedLen() int {
+	return 11
+}
+
+func (c base62Codec) Encode(dst []byte, n uint64) []byte {
+	if c.short {
+		return ID(n).AppendBase62Short(dst)
+	}
+	return ID(n).AppendBase62(dst)
+}
+
+func (base62Codec) Decode(src []byte) (uint64, error) {
+	id, err := ParseBase62Bytes(src)
+	return uint64(id), err
+}
+
+type decimalCodec struct {
+	name   string
+	padded bool
+}
+
+func (c decimalCodec) Name() string {
+	return c.name
+}
+
+func (decimalCodec) EncodedLen() int {
+	return 20
+}
+
+func (c decimalCodec) Encode(dst []byte, n uint64) []byte {
+	if c.padded {
+		return ID(n).AppendPaddedDecimal(dst)
+	}
+	return ID(n).AppendDecimal(dst)
+}
+
+func (decimalCodec) Decode(src []byte) (uint64, error) {
+	id, err := ParseDecimalBytes(src)
+	return uint64(id), err
+}
@@ -0,0 +1,140 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCodecs tests that the built-in codecs match the ID methods and round trip
+func TestCodecs(t *testing.T) {
+	tests := []struct {
+		codec  Codec
+		name   string
+		length int
+		want   func(ID) string
+	}{
+		{codec: LowerHex, name: "lowerhex", length: 16, want: ID.LowerHexString},
+		{codec: UpperHex, name: "upperhex", length: 16, want: ID.UpperHexString},
+		{codec: Base64, name: "base64", length: 11, want: ID.Base64String},
+		{codec: Base64URL, name: "base64url", length: 11, want: func(id ID) string { return URLBase64ID(id).String() }},
+		{codec: Base64Mime, name: "base64mime", length: 11, want: func(id ID) string {
+			return strings.ReplaceAll(id.Base64String(), "/", ",")
+		}},
+		{codec: Influx64, name: "influx64", length: 11, want: ID.Influx64String},
+		{codec: Crockford, name: "crockford", length: 13, want: ID.CrockfordString},
+		{codec: Base58, name: "base58", length: 11, want: ID.Base58String},
+		{codec: Base58Short, name: "base58short", length: 11, want: ID.Base58ShortString},
+		{codec: Base62, name: "base62", length: 11, want: ID.Base62String},
+		{codec: Base62Short, name: "base62short", length: 11, want: ID.Base62ShortString},
+		{codec: Decimal, name: "decimal", length: 20, want: ID.DecimalString},
+		{codec: PaddedDecimal, name: "decimal20", length: 20, want: ID.PaddedDecimalString},
+	}
+	ids := []ID{0, 1, 0xA000B00F0A023452, math.MaxInt64, math.MaxUint64}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.codec.Name() != tt.name {
+				t.Errorf("Name() = %v, want %v", tt.codec.Name(), tt.name)
+			}
+			if tt.codec.EncodedLen() != tt.length {
+				t.Errorf("EncodedLen() = %v, want %v", tt.codec.EncodedLen(), tt.length)
+			}
+			for _, id := range ids {
+				s := id.FormatWith(tt.codec)
+				if s != tt.want(id) {
+					t.Errorf("FormatWith(%v) = %v, want %v", uint64(id), s, tt.want(id))
+				}
+				if b := id.AppendFormat([]byte("x"), tt.codec); string(b) != "x"+s {
+					t.Errorf("AppendFormat(%v) = %v, want %v", uint64(id), string(b), "x"+s)
+				}
+				got, err := Parse(tt.codec, s)
+				if err != nil {
+					t.Errorf("Parse(%v) returned error %v", s, err)
+				}
+				if got != id {
+					t.Errorf("Parse(%v) = %v, want %v", s, uint64(got), uint64(id))
+				}
+			}
+			if _, err := Parse(tt.codec, strings.Repeat("#", tt.length+1)); !errors.Is(err, ErrInvalidLength) {
+				t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+			}
+			if _, err := Parse(tt.codec, strings.Repeat("#", tt.length)); !errors.Is(err, ErrInvalidCharacter) {
+				t.Errorf("expected %v, got %v", ErrInvalidCharacter, err)
+			}
+		})
+	}
+}
+
+// TestCodecByName tests looking up the built-in codecs by name
+func TestCodecByName(t *testing.T) {
+	for _, want := range []Codec{LowerHex, UpperHex, Base64, Base64URL, Base64Mime, Influx64, Crockford,
+		Base58, Base58Short, Base62, Base62Short, Decimal, PaddedDecimal} {
+		c, err := CodecByName(want.Name())
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if c != want {
+			t.Errorf("CodecByName(%v) = %v, want %v", want.Name(), c, want)
+		}
+	}
+	if _, err := CodecByName("rot13"); !errors.Is(err, ErrUnknownCodec) {
+		t.Errorf("expected %v, got %v", ErrUnknownCodec, err)
+	}
+}
+
+type testCodec struct{}
+
+func (testCodec) Name() string                       { return "test" }
+func (testCodec) EncodedLen() int                    { return 1 }
+func (testCodec) Encode(dst []byte, n uint64) []byte { return append(dst, 'x') }
+func (testCodec) Decode(src []byte) (uint64, error)  { return 42, nil }
+
+// TestRegisterCodec tests registering a custom codec and the panics on invalid registrations
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec(testCodec{})
+	defer func() {
+		registry.Lock()
+		delete(registry.codecs, "test")
+		registry.Unlock()
+	}()
+
+	c, err := CodecByName("test")
+	if err != nil || c != (testCodec{}) {
+		t.Errorf("expected test codec, got %v, %v", c, err)
+	}
+	want := []string{"base58", "base58short", "base62", "base62short", "base64", "base64mime", "base64url", "crockford",
+		"decimal", "decimal20", "influx64", "lowerhex", "test", "upperhex"}
+	if names := CodecNames(); !reflect.DeepEqual(names, want) {
+		t.Errorf("CodecNames() = %v, want %v", names, want)
+	}
+
+	for name, c := range map[string]Codec{"duplicate": testCodec{}, "nil": nil} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic")
+				}
+			}()
+			RegisterCodec(c)
+		})
+	}
+}
+
+// ExampleCodecByName is an example of selecting a codec by name, for example from configuration
+func ExampleCodecByName() {
+	c, err := CodecByName("base64url")
+	if err != nil {
+		panic(err)
+	}
+	id := ID(0xA000B00F0A023452)
+	s := id.FormatWith(c)
+	fmt.Println(s)
+	parsed, _ := Parse(c, s)
+	fmt.Println(uint64(parsed))
+	// Output:
+	// UjQCCg-wAKA
+	// 11529408624707384402
+}
@@ -1,6 +1,9 @@
 package snowflake
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // DecodedID is a snowflake ID decoded into its components
 type DecodedID struct {
@@ -8,6 +11,12 @@ type DecodedID struct {
 	Timestamp uint64
 	MachineID uint64
 	Sequence  uint64
+	// Time is the Timestamp converted to a time using the epoch and unit of the layout
+	Time time.Time
+	// Node holds the values of the node fields of the layout, the fields that the layout does not use are empty
+	Node [MaxNodeFields]FieldValue
+	// Extra holds the values of the extra fields of the layout, the fields that the layout does not use are empty
+	Extra [MaxExtraFields]FieldValue
 }
 
 // String returns a string representation of the decoded ID
@@ -16,11 +25,7 @@ func (id DecodedID) String() string {
 }
 
 // DecodeID decodes a snowflake ID into its components
+// Use Layout.Decode to decode IDs without a generator
 func (g *Generator) DecodeID(id ID) DecodedID {
-	return DecodedID{
-		ID:        uint64(id),
-		Timestamp: uint64(id) >> timeShift,
-		MachineID: uint64(id) >> g.machineIDShift & g.machineIDMask,
-		Sequence:  uint64(id) & g.sequenceMask,
-	}
+	return g.layout.Decode(id)
 }
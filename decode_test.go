@@ -53,6 +53,7 @@ func TestGenerator_DecodeID(t *testing.T) {
 				Timestamp: 0,
 				MachineID: 2,
 				Sequence:  1,
+				Time:      time.UnixMilli(1709247600000).UTC(),
 			},
 		},
 		{
@@ -66,6 +67,7 @@ func TestGenerator_DecodeID(t *testing.T) {
 				Timestamp: 1,
 				MachineID: 2,
 				Sequence:  0,
+				Time:      time.UnixMilli(1709247600001).UTC(),
 			},
 		},
 	}
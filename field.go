@@ -0,0 +1,158 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// MaxNodeFields is the maximum number of named fields that a machine ID can be divided into
+	MaxNodeFields = 4
+	// MaxExtraFields is the maximum number of extra named fields that a layout can embed in IDs
+	MaxExtraFields = 4
+)
+
+var (
+	// ErrInvalidNodeFields is returned when the node fields of a layout do not divide its machine ID bits
+	ErrInvalidNodeFields = errors.New("invalid node fields")
+	// ErrNodeFieldCount is returned when the number of node field values does not match the node fields of the layout
+	ErrNodeFieldCount = errors.New("wrong number of node field values")
+	// ErrNodeFieldTooLarge is returned when a node field value does not fit in the bits of its field
+	ErrNodeFieldTooLarge = errors.New("node field value is too large")
+	// ErrInvalidExtraFields is returned when the extra fields of a layout are invalid
+	ErrInvalidExtraFields = errors.New("invalid extra fields")
+	// ErrExtraFieldCount is returned when the number of extra field values does not match the extra fields of the
+	// layout
+	ErrExtraFieldCount = errors.New("wrong number of extra field values")
+	// ErrExtraFieldTooLarge is returned when an extra field value does not fit in the bits of its field
+	ErrExtraFieldTooLarge = errors.New("extra field value is too large")
+)
+
+// Field is a named bit field of an ID, such as a datacenter, a worker or an entity type
+type Field struct {
+	Name string
+	Bits uint64
+}
+
+// FieldValue is the value of a named field of a decoded ID
+type FieldValue struct {
+	Name  string
+	Value uint64
+}
+
+// fieldCount returns the number of used fields, the unused fields have zero bits
+func fieldCount(fields []Field) int {
+	n := 0
+	for n < len(fields) && fields[n].Bits > 0 {
+		n++
+	}
+	return n
+}
+
+// fieldBits returns the sum of the bits of the fields
+func fieldBits(fields []Field) uint64 {
+	var bits uint64
+	for _, field := range fields {
+		bits += field.Bits
+	}
+	return bits
+}
+
+// validateFields returns an error wrapping errInvalid if the fields are not named uniquely, if a used field follows
+// an unused field, or if the fields have more than 63 bits
+func validateFields(fields []Field, errInvalid error) error {
+	n := fieldCount(fields)
+	var bits uint64
+	for i, field := range fields {
+		switch {
+		case i >= n && field != Field{}:
+			return fmt.Errorf("%w: field %d has no bits", errInvalid, n)
+		case i >= n:
+			continue
+		case field.Name == "":
+			return fmt.Errorf("%w: field %d has no name", errInvalid, i)
+		case field.Bits > 63 || bits+field.Bits > 63:
+			// Checking each field before adding it keeps the sum from overflowing
+			return fmt.Errorf("%w: the fields have more than 63 bits", errInvalid)
+		}
+		for _, other := range fields[:i] {
+			if other.Name == field.Name {
+				return fmt.Errorf("%w: duplicate field %q", errInvalid, field.Name)
+			}
+		}
+		bits += field.Bits
+	}
+	return nil
+}
+
+// composeFields returns the values of the fields packed from most to least significant.
+// Returns an error wrapping errCount or errTooLarge if the values do not match the fields.
+func composeFields(fields []Field, values []uint64, errCount, errTooLarge error) (uint64, error) {
+	n := fieldCount(fields)
+	if len(values) != n {
+		return 0, fmt.Errorf("%w: got %d values for %d fields", errCount, len(values), n)
+	}
+	var packed uint64
+	for i, value := range values {
+		field := fields[i]
+		if value > mask(field.Bits) {
+			return 0, fmt.Errorf("%w: %s is %d, the maximum is %d", errTooLarge, field.Name, value, mask(field.Bits))
+		}
+		packed = packed<<field.Bits | value
+	}
+	return packed, nil
+}
+
+// decodeFields stores the values of the fields packed in the lower bits of packed into values
+func decodeFields(fields []Field, packed uint64, values []FieldValue) {
+	shift := fieldBits(fields)
+	for i := 0; i < fieldCount(fields); i++ {
+		field := fields[i]
+		shift -= field.Bits
+		values[i] = FieldValue{Name: field.Name, Value: packed >> shift & mask(field.Bits)}
+	}
+}
+
+// validateNodeFields returns an error if the valid node fields do not divide the machine ID bits
+func (l Layout) validateNodeFields() error {
+	if bits := fieldBits(l.NodeFields[:]); bits > 0 && bits != l.MachineIDBits {
+		return fmt.Errorf("%w: the fields have %d bits, the machine ID has %d bits", ErrInvalidNodeFields, bits,
+			l.MachineIDBits)
+	}
+	return nil
+}
+
+// NodeID returns the machine ID composed of the values of the node fields, in the order of the fields.
+// Returns an error if the number of values does not match the fields or a value does not fit in its field.
+func (l Layout) NodeID(values ...uint64) (uint64, error) {
+	return composeFields(l.NodeFields[:], values, ErrNodeFieldCount, ErrNodeFieldTooLarge)
+}
+
+// extraBits returns the number of bits of the extra fields
+func (l Layout) extraBits() uint64 {
+	return fieldBits(l.ExtraFields[:])
+}
+
+// Extra returns the lower bits of an ID composed of the values of the extra fields, in the order of the fields.
+// Returns an error if the number of values does not match the fields or a value does not fit in its field.
+func (l Layout) Extra(values ...uint64) (uint64, error) {
+	return composeFields(l.ExtraFields[:], values, ErrExtraFieldCount, ErrExtraFieldTooLarge)
+}
+
+// Field returns the value of the named node or extra field of the decoded ID, and whether the layout has the field
+func (id DecodedID) Field(name string) (uint64, bool) {
+	if name == "" {
+		return 0, false
+	}
+	for _, field := range id.Node {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	for _, field := range id.Extra {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	return 0, false
+}
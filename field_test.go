@@ -0,0 +1,253 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestGenerator_NodeFields tests a generator with a machine ID divided into a datacenter and a worker
+func TestGenerator_NodeFields(t *testing.T) {
+	generator, err := NewGenerator(0, WithLayout(TwitterLayout),
+		WithNodeFields(Field{Name: "datacenter", Bits: 5}, Field{Name: "worker", Bits: 5}), WithNodeID(3, 17))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	layout := TwitterLayout
+	layout.NodeFields = [MaxNodeFields]Field{{Name: "datacenter", Bits: 5}, {Name: "worker", Bits: 5}}
+	if generator.Layout() != layout {
+		t.Errorf("got %v, want %v", generator.Layout(), layout)
+	}
+	generator.timeFunc = func() uint64 {
+		return 1656432460105
+	}
+	id, err := generator.NextID()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	decoded := layout.Decode(id)
+	if decoded.MachineID != 3<<5|17 {
+		t.Errorf("expected machine ID %v, got %v", 3<<5|17, decoded.MachineID)
+	}
+	if got := TwitterLayout.Decode(id); got.MachineID != decoded.MachineID || got.Sequence != decoded.Sequence {
+		t.Errorf("expected TwitterLayout to decode machine ID %v, got %v", decoded.MachineID, got.MachineID)
+	}
+	want := [MaxNodeFields]FieldValue{{Name: "datacenter", Value: 3}, {Name: "worker", Value: 17}}
+	if decoded.Node != want {
+		t.Errorf("got %v, want %v", decoded.Node, want)
+	}
+	if v, ok := decoded.Field("datacenter"); !ok || v != 3 {
+		t.Errorf("Field(datacenter) = %v, %v, want 3, true", v, ok)
+	}
+	if v, ok := decoded.Field("region"); ok || v != 0 {
+		t.Errorf("Field(region) = %v, %v, want 0, false", v, ok)
+	}
+	if decoded != generator.DecodeID(id) {
+		t.Errorf("got %v, want %v", generator.DecodeID(id), decoded)
+	}
+}
+
+// TestGenerator_NodeFields_Errors tests that the generator validates the node fields and their values
+func TestGenerator_NodeFields_Errors(t *testing.T) {
+	fields := WithNodeFields(Field{Name: "region", Bits: 2}, Field{Name: "datacenter", Bits: 3},
+		Field{Name: "worker", Bits: 5})
+	tests := []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{name: "Valid", opts: []Option{fields, WithNodeID(3, 7, 31)}},
+		{name: "Region too large", opts: []Option{fields, WithNodeID(4, 7, 31)}, want: ErrNodeFieldTooLarge},
+		{name: "Datacenter too large", opts: []Option{fields, WithNodeID(3, 8, 31)}, want: ErrNodeFieldTooLarge},
+		{name: "Worker too large", opts: []Option{fields, WithNodeID(3, 7, 32)}, want: ErrNodeFieldTooLarge},
+		{name: "Too few values", opts: []Option{fields, WithNodeID(3, 7)}, want: ErrNodeFieldCount},
+		{name: "Too many values", opts: []Option{fields, WithNodeID(3, 7, 31, 1)}, want: ErrNodeFieldCount},
+		{name: "Too many fields", opts: []Option{WithNodeFields(make([]Field, MaxNodeFields+1)...)}, want: ErrInvalidNodeFields},
+		{name: "Unnamed field", opts: []Option{WithNodeFields(Field{Bits: 5}, Field{Name: "worker", Bits: 5})}, want: ErrInvalidNodeFields},
+		{name: "Duplicate field", opts: []Option{WithNodeFields(Field{Name: "worker", Bits: 5}, Field{Name: "worker", Bits: 5})}, want: ErrInvalidNodeFields},
+		{name: "Machine bits changed", opts: []Option{fields, WithMachineIDBits(12)}, want: ErrInvalidNodeFields},
+		{name: "Field without bits", opts: []Option{WithNodeFields(Field{Name: "datacenter"}, Field{Name: "worker", Bits: 5})}, want: ErrInvalidNodeFields},
+		{name: "Field too large", opts: []Option{WithNodeFields(Field{Name: "datacenter", Bits: 64})}, want: ErrInvalidNodeFields},
+		{name: "Fields overflow", opts: []Option{WithNodeFields(Field{Name: "a", Bits: 1 << 63}, Field{Name: "b", Bits: 1 << 63}, Field{Name: "c", Bits: 10})}, want: ErrInvalidNodeFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(0, tt.opts...); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	_, err := NewGenerator(0, fields, WithNodeID(3, 8, 31))
+	if err == nil || err.Error() != "node field value is too large: datacenter is 8, the maximum is 7" {
+		t.Errorf("got %v", err)
+	}
+}
+
+// TestDecodedID_NodeFields_Format tests that the node fields are part of the %+v and JSON representations
+func TestDecodedID_NodeFields_Format(t *testing.T) {
+	layout := TwitterLayout
+	layout.NodeFields = [MaxNodeFields]Field{{Name: "datacenter", Bits: 5}, {Name: "worker", Bits: 5}}
+	decoded := layout.Decode(1541815603606036480)
+
+	want := "ID: 1541815603606036480, Timestamp: 367597485448 (2022-06-28T16:07:40.105Z), MachineID: 378, Sequence: 0, datacenter: 11, worker: 26"
+	if got := fmt.Sprintf("%+v", decoded); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	wantJSON := `{"id":"1541815603606036480","timestamp":"2022-06-28T16:07:40.105Z","machine_id":378,"sequence":0,"node":{"datacenter":11,"worker":26}}`
+	if string(b) != wantJSON {
+		t.Errorf("got %s, want %s", b, wantJSON)
+	}
+}
+
+// ExampleWithNodeFields is an example of a generator with a machine ID divided into a datacenter and a worker
+func ExampleWithNodeFields() {
+	generator, err := NewGenerator(0, WithLayout(TwitterLayout), WithEpoch(time.UnixMilli(0)),
+		WithNodeFields(Field{Name: "datacenter", Bits: 5}, Field{Name: "worker", Bits: 5}), WithNodeID(3, 17))
+	if err != nil {
+		panic(err)
+	}
+	id, _ := generator.NextID()
+	datacenter, _ := generator.DecodeID(id).Field("datacenter")
+	worker, _ := generator.DecodeID(id).Field("worker")
+	fmt.Println(datacenter, worker)
+	// Output:
+	// 3 17
+}
+
+// TestGenerator_ExtraFields tests a generator that embeds an entity type and a shard in its IDs
+func TestGenerator_ExtraFields(t *testing.T) {
+	generator, err := NewGenerator(5, WithEpoch(time.UnixMilli(0)),
+		WithExtraFields(Field{Name: "type", Bits: 4}, Field{Name: "shard", Bits: 2}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	layout := generator.Layout()
+	if layout.MachineIDBits != 10 || layout.SequenceBits != 6 {
+		t.Errorf("expected 10 machine ID bits and 6 sequence bits, got %v and %v", layout.MachineIDBits, layout.SequenceBits)
+	}
+	generator.timeFunc = func() uint64 {
+		return 1000
+	}
+
+	var previous ID
+	for sequence := uint64(0); sequence < 3; sequence++ {
+		id, err := generator.NextIDWithFields(9-sequence, sequence)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if id <= previous {
+			t.Errorf("expected %v to be greater than %v", uint64(id), uint64(previous))
+		}
+		previous = id
+		if want, _ := layout.ComposeWithFields(1000, 5, sequence, 9-sequence, sequence); id != want {
+			t.Errorf("got %v, want %v", uint64(id), uint64(want))
+		}
+		decoded := generator.DecodeID(id)
+		if decoded.Timestamp != 1000 || decoded.MachineID != 5 || decoded.Sequence != sequence {
+			t.Errorf("got %v, want timestamp 1000, machine ID 5 and sequence %v", decoded, sequence)
+		}
+		if v, ok := decoded.Field("type"); !ok || v != 9-sequence {
+			t.Errorf("Field(type) = %v, %v, want %v, true", v, ok, 9-sequence)
+		}
+		if v, ok := decoded.Field("shard"); !ok || v != sequence {
+			t.Errorf("Field(shard) = %v, %v, want %v, true", v, ok, sequence)
+		}
+	}
+
+	id, err := generator.NextID()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if decoded := generator.DecodeID(id); decoded.Sequence != 3 || decoded.Extra[0] != (FieldValue{Name: "type"}) {
+		t.Errorf("expected sequence 3 and a zero type, got %v", decoded)
+	}
+
+	id, err = generator.BlockingNextIDWithFields(context.Background(), 15, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id&0x3F != 0x3F {
+		t.Errorf("expected the lower 6 bits to hold the fields, got %x", uint64(id))
+	}
+
+	if _, err = generator.NextIDWithFields(16, 0); !errors.Is(err, ErrExtraFieldTooLarge) {
+		t.Errorf("expected %v, got %v", ErrExtraFieldTooLarge, err)
+	}
+	if _, err = generator.BlockingNextIDWithFields(context.Background(), 1); !errors.Is(err, ErrExtraFieldCount) {
+		t.Errorf("expected %v, got %v", ErrExtraFieldCount, err)
+	}
+}
+
+// TestGenerator_ExtraFields_Errors tests that the generator rejects extra fields that do not fit
+func TestGenerator_ExtraFields_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{name: "From the machine ID", opts: []Option{WithExtraFields(Field{Name: "type", Bits: 4}), WithMachineIDBits(6)}},
+		{name: "All remaining bits", opts: []Option{WithExtraFields(Field{Name: "type", Bits: 12})}, want: ErrSequenceBitsTooSmall},
+		{name: "Too large", opts: []Option{WithExtraFields(Field{Name: "type", Bits: 4}), WithMachineIDBits(6), WithTimestampBits(43)}, want: ErrLayoutTooLarge},
+		{name: "Too many fields", opts: []Option{WithExtraFields(make([]Field, MaxExtraFields+1)...)}, want: ErrInvalidExtraFields},
+		{name: "Unnamed field", opts: []Option{WithExtraFields(Field{Bits: 4})}, want: ErrInvalidExtraFields},
+		{name: "Field without bits", opts: []Option{WithExtraFields(Field{Name: "type"}, Field{Name: "shard", Bits: 2})}, want: ErrInvalidExtraFields},
+		{name: "Field too large", opts: []Option{WithExtraFields(Field{Name: "type", Bits: 1 << 63})}, want: ErrInvalidExtraFields},
+		{name: "Fields overflow", opts: []Option{WithExtraFields(Field{Name: "a", Bits: 1 << 63}, Field{Name: "b", Bits: 1 << 63}, Field{Name: "c", Bits: 3})}, want: ErrInvalidExtraFields},
+		{name: "Fields too large", opts: []Option{WithExtraFields(Field{Name: "a", Bits: 32}, Field{Name: "b", Bits: 32})}, want: ErrInvalidExtraFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(0, tt.opts...); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestDecodedID_ExtraFields_Format tests that the extra fields are part of the %+v and JSON representations
+func TestDecodedID_ExtraFields_Format(t *testing.T) {
+	layout := DefaultLayout
+	layout.SequenceBits = 8
+	layout.ExtraFields = [MaxExtraFields]Field{{Name: "type", Bits: 4}}
+	id, err := layout.ComposeWithFields(1, 2, 3, 4)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	decoded := layout.Decode(id)
+
+	want := "ID: 4202548, Timestamp: 1 (2024-02-29T23:00:00.001Z), MachineID: 2, Sequence: 3, type: 4"
+	if got := fmt.Sprintf("%+v", decoded); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	wantJSON := `{"id":"4202548","timestamp":"2024-02-29T23:00:00.001Z","machine_id":2,"sequence":3,"fields":{"type":4}}`
+	if string(b) != wantJSON {
+		t.Errorf("got %s, want %s", b, wantJSON)
+	}
+}
+
+// ExampleGenerator_NextIDWithFields is an example of embedding an entity type in IDs
+func ExampleGenerator_NextIDWithFields() {
+	generator, err := NewGenerator(1, WithExtraFields(Field{Name: "type", Bits: 4}))
+	if err != nil {
+		panic(err)
+	}
+	id, _ := generator.NextIDWithFields(7)
+	entityType, _ := generator.DecodeID(id).Field("type")
+	fmt.Println(entityType)
+	// Output:
+	// 7
+}
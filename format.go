@@ -0,0 +1,151 @@
+package snowflake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format implements fmt.Formatter.
+//
+//	%d     decimal
+//	%x %X  16 digit lower and upper case hex, the # flag adds a 0x or 0X prefix
+//	%s %v  Influx64
+//	%q     quoted Influx64
+//	%+v    the components decoded with DefaultLayout
+//
+// Width, precision and the -, 0, + and space flags are honoured as for integers and strings.
+// Other verbs, and %#v, format the ID as a uint64.
+func (id ID) Format(f fmt.State, verb rune) {
+	var b [24]byte
+	switch {
+	case verb == 'd':
+		formatNumber(f, signPrefix(f), id.AppendDecimal(b[:0]))
+	case verb == 'x' && f.Flag('#'):
+		formatNumber(f, "0x", id.AppendLowerHex(b[:0]))
+	case verb == 'x':
+		formatNumber(f, signPrefix(f), id.AppendLowerHex(b[:0]))
+	case verb == 'X' && f.Flag('#'):
+		formatNumber(f, "0X", id.AppendUpperHex(b[:0]))
+	case verb == 'X':
+		formatNumber(f, signPrefix(f), id.AppendUpperHex(b[:0]))
+	case verb == 'v' && f.Flag('+'):
+		formatText(f, []byte(fmt.Sprintf("%+v", DefaultLayout.Decode(id))))
+	case verb == 's' || verb == 'v' && !f.Flag('#'):
+		formatText(f, id.AppendInflux64(b[:0]))
+	case verb == 'q':
+		formatText(f, strconv.AppendQuote(b[:0], id.Influx64String()))
+	default:
+		fmt.Fprintf(f, directive(f, verb), uint64(id))
+	}
+}
+
+// Format implements fmt.Formatter.
+// The %+v verb adds the time of the timestamp in RFC 3339 format and the named fields, %s and %v return String and %q quotes it.
+// Other verbs format the fields of the struct.
+func (id DecodedID) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		b := fmt.Appendf(nil, "ID: %d, Timestamp: %d (%s), MachineID: %d, Sequence: %d",
+			id.ID, id.Timestamp, id.Time.UTC().Format(time.RFC3339Nano), id.MachineID, id.Sequence)
+		for _, field := range append(id.Node[:], id.Extra[:]...) {
+			if field.Name != "" {
+				b = fmt.Appendf(b, ", %s: %d", field.Name, field.Value)
+			}
+		}
+		formatText(f, b)
+	case verb == 's' || verb == 'v' && !f.Flag('#'):
+		formatText(f, []byte(id.String()))
+	case verb == 'q':
+		formatText(f, []byte(strconv.Quote(id.String())))
+	case verb == 'v':
+		// The Go syntax representation names DecodedID instead of the method-less decodedID
+		type decodedID DecodedID
+		s := fmt.Sprintf("%#v", decodedID(id))
+		_, _ = f.Write([]byte(strings.Replace(s, "decodedID", "DecodedID", 1)))
+	default:
+		// decodedID has the fields of DecodedID without its methods, so fmt formats the struct
+		type decodedID DecodedID
+		fmt.Fprintf(f, directive(f, verb), decodedID(id))
+	}
+}
+
+// signPrefix returns the sign that the + and space flags add to a number
+func signPrefix(f fmt.State) string {
+	switch {
+	case f.Flag('+'):
+		return "+"
+	case f.Flag(' '):
+		return " "
+	}
+	return ""
+}
+
+// formatNumber writes the prefix and digits, zero extended to the precision and padded to the width
+func formatNumber(f fmt.State, prefix string, digits []byte) {
+	zeros := 0
+	precision, hasPrecision := f.Precision()
+	if hasPrecision && precision > len(digits) {
+		zeros = precision - len(digits)
+	}
+	padding := 0
+	if width, ok := f.Width(); ok && width > len(prefix)+zeros+len(digits) {
+		padding = width - len(prefix) - zeros - len(digits)
+	}
+	// As for integers, the 0 flag pads with zeros after the prefix unless a precision is given
+	if f.Flag('0') && !f.Flag('-') && !hasPrecision {
+		zeros, padding = zeros+padding, 0
+	}
+	if !f.Flag('-') {
+		writeRepeated(f, ' ', padding)
+	}
+	_, _ = f.Write([]byte(prefix))
+	writeRepeated(f, '0', zeros)
+	_, _ = f.Write(digits)
+	if f.Flag('-') {
+		writeRepeated(f, ' ', padding)
+	}
+}
+
+// formatText writes the text, truncated to the precision and padded to the width
+func formatText(f fmt.State, text []byte) {
+	if precision, ok := f.Precision(); ok && precision < len(text) {
+		text = text[:precision]
+	}
+	padding := 0
+	if width, ok := f.Width(); ok && width > len(text) {
+		padding = width - len(text)
+	}
+	if !f.Flag('-') {
+		writeRepeated(f, ' ', padding)
+	}
+	_, _ = f.Write(text)
+	if f.Flag('-') {
+		writeRepeated(f, ' ', padding)
+	}
+}
+
+func writeRepeated(f fmt.State, c byte, n int) {
+	for ; n > 0; n-- {
+		_, _ = f.Write([]byte{c})
+	}
+}
+
+// directive rebuilds the formatting directive of f and verb, so a value can be formatted with the same flags
+func directive(f fmt.State, verb rune) string {
+	b := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b = append(b, byte(flag))
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(width), 10)
+	}
+	if precision, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(precision), 10)
+	}
+	return string(append(b, string(verb)...))
+}
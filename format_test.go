@@ -0,0 +1,94 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestID_Format tests the fmt verbs, flags and widths of the ID
+func TestID_Format(t *testing.T) {
+	const id = ID(0xA000B00F0A023452)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%25d", want: "     11529408624707384402"},
+		{format: "%-25d|", want: "11529408624707384402     |"},
+		{format: "%025d", want: "0000011529408624707384402"},
+		{format: "%+d", want: "+11529408624707384402"},
+		{format: "%x", want: "a000b00f0a023452"},
+		{format: "%X", want: "A000B00F0A023452"},
+		{format: "%#x", want: "0xa000b00f0a023452"},
+		{format: "%#X", want: "0XA000B00F0A023452"},
+		{format: "%20x", want: "    a000b00f0a023452"},
+		{format: "%#020x", want: "0x00a000b00f0a023452"},
+		{format: "%s", want: "A00h0xA0ZHI"},
+		{format: "%v", want: "A00h0xA0ZHI"},
+		{format: "%15v|", want: "    A00h0xA0ZHI|"},
+		{format: "%-15s|", want: "A00h0xA0ZHI    |"},
+		{format: "%q", want: `"A00h0xA0ZHI"`},
+		{format: "%+v", want: "ID: 11529408624707384402, Timestamp: 2748825222184 (2111-04-10T01:33:42.184Z), MachineID: 35, Sequence: 1106"},
+		{format: "%#v", want: "0xa000b00f0a023452"},
+		{format: "%o", want: "1200005400741200432122"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, id); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := fmt.Sprintf("%x", ID(1)); got != "0000000000000001" {
+		t.Errorf("got %v, want %v", got, "0000000000000001")
+	}
+	if got := fmt.Sprint([]ID{1, 2}); got != "[00000000001 00000000002]" {
+		t.Errorf("got %v, want %v", got, "[00000000001 00000000002]")
+	}
+}
+
+// TestDecodedID_Format tests the fmt verbs of the DecodedID
+func TestDecodedID_Format(t *testing.T) {
+	id := DecodedID{ID: 4194305, Timestamp: 1, MachineID: 0, Sequence: 1, Time: time.UnixMilli(1709247600001).UTC()}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%v", want: "ID: 4194305, Timestamp: 1, MachineID: 0, Sequence: 1"},
+		{format: "%s", want: "ID: 4194305, Timestamp: 1, MachineID: 0, Sequence: 1"},
+		{format: "%+v", want: "ID: 4194305, Timestamp: 1 (2024-02-29T23:00:00.001Z), MachineID: 0, Sequence: 1"},
+		{format: "%q", want: `"ID: 4194305, Timestamp: 1, MachineID: 0, Sequence: 1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, id); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Other verbs format the fields of the struct
+	if got, want := fmt.Sprintf("%d", id), "{4194305 1 0 1 {1000000 63844844400 0} "; !strings.HasPrefix(got, want) {
+		t.Errorf("got %v, want prefix %v", got, want)
+	}
+	if got, want := fmt.Sprintf("%#v", id), "snowflake.DecodedID{ID:0x400001, Timestamp:0x1, MachineID:0x0, Sequence:0x1, "+
+		"Time:time.Date(2024, time.February, 29, 23, 0, 0, 1000000, time.UTC), "; !strings.HasPrefix(got, want) {
+		t.Errorf("got %v, want prefix %v", got, want)
+	}
+}
+
+// ExampleID_Format is an example of formatting an ID with the fmt verbs
+func ExampleID_Format() {
+	id := ID(0xA000B00F0A023452)
+	fmt.Printf("%d\n", id)
+	fmt.Printf("%#x\n", id)
+	fmt.Printf("%v\n", id)
+	fmt.Printf("%+v\n", ID(4194305))
+	// Output:
+	// 11529408624707384402
+	// 0xa000b00f0a023452
+	// A00h0xA0ZHI
+	// ID: 4194305, Timestamp: 1 (2024-02-29T23:00:00.001Z), MachineID: 0, Sequence: 1
+}
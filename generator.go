@@ -3,6 +3,7 @@ package snowflake
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"time"
 )
@@ -18,11 +19,23 @@ var (
 	ErrOutOfSequence = errors.New("sequence number overflow")
 	// ErrTimeBeforeEpoch is returned when the time is before the epoch
 	ErrTimeBeforeEpoch = errors.New("time is before epoch")
+	// ErrInt64Overflow is returned by an int64 safe generator when the timestamp would set the sign bit of the ID.
+	// It wraps ErrTimestampOverflow.
+	ErrInt64Overflow = fmt.Errorf("%w: ID would overflow int64", ErrTimestampOverflow)
+	// ErrInvalidThreshold is returned when the exhaustion warning threshold is not between 0 and 1
+	ErrInvalidThreshold = errors.New("threshold is not between 0 and 1")
+	// ErrUnsafeInteger is returned when the layout of a JavaScript safe generator has more than 53 bits
+	ErrUnsafeInteger = errors.New("ID would exceed the maximum safe integer of JavaScript")
+	// ErrSafeIntegerOverflow is returned by a JavaScript safe generator when the timestamp would make the ID exceed
+	// MaxSafeInteger. It wraps ErrTimestampOverflow.
+	ErrSafeIntegerOverflow = fmt.Errorf("%w: ID would exceed the maximum safe integer of JavaScript", ErrTimestampOverflow)
 )
 
-const (
-	timeShift = 22
-)
+// MaxSafeInteger is the largest integer that JavaScript numbers represent exactly, Number.MAX_SAFE_INTEGER
+const MaxSafeInteger ID = 1<<53 - 1
+
+// ExhaustionFunc is called with the used fraction of the timestamp range and the time at which the layout runs out
+type ExhaustionFunc func(usage float64, exhaustion time.Time)
 
 // Option is a function that configures the generator
 type Option func(*Generator)
@@ -34,33 +47,72 @@ func defaultTimeFunc() uint64 {
 	return uint64(time.Now().UnixMilli())
 }
 
-func defaultSleepFunc() {
-	nano := time.Duration(time.Now().UnixNano())
-	milli := nano.Truncate(time.Millisecond)
-	milli = milli + time.Millisecond
-	delay := milli - nano
-	time.Sleep(delay + 1*time.Nanosecond)
+// tickSleepFunc returns a function that waits with the strategy until the next tick of unitMillis milliseconds since
+// the epoch of the wall clock
+func tickSleepFunc(epoch, unitMillis int64, strategy SleepStrategy) func() {
+	return func() {
+		unit := time.Duration(unitMillis) * time.Millisecond
+		elapsed := time.Duration(time.Now().UnixNano()) - time.Duration(epoch)*time.Millisecond
+		delay := elapsed.Truncate(unit) + unit - elapsed
+		strategy(delay + 1*time.Nanosecond)
+	}
+}
+
+// timeSourceSleepFunc returns a function that waits with the strategy until the next tick of the time source, which only
+// has millisecond precision
+func timeSourceSleepFunc(timeFunc TimeFunc, epoch, unitMillis int64, strategy SleepStrategy) func() {
+	return func() {
+		elapsed := int64(timeFunc()) - epoch
+		strategy(time.Duration(unitMillis-elapsed%unitMillis) * time.Millisecond)
+	}
 }
 
-func exactSleepFunc() {
-	currentMilli := time.Now().UnixMilli()
-	for currentMilli == time.Now().UnixMilli() {
+// driftTicks returns the number of ticks of unitMillis milliseconds that the drift allows ahead of the clock, rounded up
+// so that a drift shorter than a tick still allows a tick
+func driftTicks(drift time.Duration, unitMillis int64) uint64 {
+	if drift <= 0 {
+		return 0
 	}
+	unit := time.Duration(unitMillis) * time.Millisecond
+	return uint64((drift + unit - 1) / unit)
 }
 
 // Generator is a snowflake ID generator
 type Generator struct {
-	currentID      atomic.Uint64
-	machineID      uint64
-	sequenceMask   uint64
-	machineIDMask  uint64
-	machineIDBits  uint64
-	machineIDShift uint64
-	epoch          int64
-	timeFunc       TimeFunc
-	sleepFunc      func()
-	drift          bool
-	duration       time.Duration
+	// currentID holds the state of the generator, the last timestamp above the last sequence number
+	currentID         atomic.Uint64
+	layout            Layout
+	machineID         uint64
+	machineIDPart     uint64
+	sequenceBits      uint64
+	sequenceMask      uint64
+	timestampShift    uint64
+	sequenceShift     uint64
+	epoch             int64
+	unitMillis        int64
+	driftTicks        uint64
+	timeFunc          TimeFunc
+	sleepFunc         func()
+	sleepStrategy     SleepStrategy
+	timeSource        bool
+	drift             bool
+	int64Safe         bool
+	javaScriptSafe    bool
+	overflowErr       error
+	warnThreshold     float64
+	warnFunc          ExhaustionFunc
+	warnTimestamp     uint64
+	warned            atomic.Bool
+	rollbackPolicy    RollbackPolicy
+	rollbackTolerance time.Duration
+	waitFunc          func(context.Context, time.Duration) error
+	clockHighest      atomic.Uint64
+	clockLast         atomic.Uint64
+	rollbacks         atomic.Uint64
+	optionErr         error
+	nodeValues        []uint64
+	timestampMask     uint64
+	duration          time.Duration
 }
 
 // NewGenerator creates a new snowflake ID generator
@@ -68,106 +120,336 @@ type Generator struct {
 // opts are the options to configure the generator
 // Returns a new snowflake ID generator
 // Returns an error if the machineID is too large for the number of bits
-// Returns an error if the machineIDBits is invalid
+// Returns an error if the layout is invalid
 func NewGenerator(machineID uint64, opts ...Option) (*Generator, error) {
 	g := &Generator{
 		timeFunc:      defaultTimeFunc,
-		machineIDBits: 10,
+		layout:        DefaultLayout,
 		machineID:     machineID,
-		sleepFunc:     defaultSleepFunc,
-		epoch:         1709247600000,
+		waitFunc:      waitContext,
+		sleepStrategy: time.Sleep,
 	}
 
 	for _, opt := range opts {
 		opt(g)
 	}
 
-	maxMachineID := uint64(1<<g.machineIDBits - 1)
+	if g.optionErr != nil {
+		return nil, g.optionErr
+	}
+
+	if g.int64Safe && g.layout.bits() == 64 {
+		g.layout.TimestampBits--
+	}
 
-	if g.machineIDBits < 1 {
-		return nil, ErrMachineBitsTooSmall
+	if err := g.layout.Validate(); err != nil {
+		return nil, err
 	}
 
-	if g.machineIDBits > 21 {
-		return nil, ErrMachineBitsTooLarge
+	g.overflowErr = ErrTimestampOverflow
+	switch {
+	case g.javaScriptSafe && g.layout.bits() > 53:
+		return nil, fmt.Errorf("%w: the layout has %d bits", ErrUnsafeInteger, g.layout.bits())
+	case g.javaScriptSafe:
+		g.overflowErr = ErrSafeIntegerOverflow
+	case g.int64Safe:
+		g.overflowErr = ErrInt64Overflow
+	}
+
+	if g.nodeValues != nil {
+		machineID, err := g.layout.NodeID(g.nodeValues...)
+		if err != nil {
+			return nil, err
+		}
+		g.machineID = machineID
 	}
 
-	if g.machineID > maxMachineID {
+	if g.machineID > mask(g.layout.MachineIDBits) {
 		return nil, ErrMachineIDTooLarge
 	}
 
-	g.machineIDMask = maxMachineID
-	g.sequenceMask = 1<<(timeShift-g.machineIDBits) - 1
-	g.machineIDShift = timeShift - g.machineIDBits
+	g.epoch = g.layout.Epoch.UnixMilli()
+	g.unitMillis = g.layout.unitMillis()
+	g.driftTicks = driftTicks(g.duration, g.unitMillis)
+	g.sleepFunc = tickSleepFunc(g.epoch, g.unitMillis, g.sleepStrategy)
+	if g.timeSource {
+		g.sleepFunc = timeSourceSleepFunc(g.timeFunc, g.epoch, g.unitMillis, g.sleepStrategy)
+	}
+	g.machineIDPart = g.machineID << g.layout.machineIDShift()
+	g.sequenceBits = g.layout.SequenceBits
+	g.sequenceMask = mask(g.layout.SequenceBits)
+	g.timestampShift = g.layout.timestampShift()
+	g.timestampMask = mask(g.layout.TimestampBits)
+	g.warnTimestamp = uint64(g.warnThreshold * float64(g.timestampMask))
+	g.sequenceShift = g.layout.sequenceShift()
 
 	return g, nil
 }
 
-// NextID generates a new snowflake ID
+// Exhaustion returns the time at which the timestamp of the generator no longer fits in its layout, from then on
+// NextID returns ErrTimestampOverflow
+func (g *Generator) Exhaustion() time.Time {
+	return g.layout.Exhaustion()
+}
+
+// Layout returns the layout of the IDs of the generator
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
+// NextID generates a new snowflake ID.
+// With the RollbackWait policy it blocks for up to the tolerance when the clock moved backwards, use BlockingNextID to
+// cancel the wait.
 func (g *Generator) NextID() (ID, error) {
+	return g.nextID(context.Background(), 0)
+}
 
-	now := int64(g.timeFunc()) - g.epoch
+// NextIDWithFields generates a new snowflake ID with the values of the extra fields of the layout, in the order of the
+// fields. Returns an error if the number of values does not match the fields or a value does not fit in its field.
+func (g *Generator) NextIDWithFields(values ...uint64) (ID, error) {
+	extra, err := g.layout.Extra(values...)
+	if err != nil {
+		return 0, err
+	}
+	return g.nextID(context.Background(), extra)
+}
+
+// nextID generates a new snowflake ID with the packed values of the extra fields in its lower bits.
+// A rollback wait is canceled with ctx.
+func (g *Generator) nextID(ctx context.Context, extra uint64) (ID, error) {
+	clock, err := g.readClock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := int64(clock) - g.epoch
 
 	if now < 0 {
 		return 0, ErrTimeBeforeEpoch
 	}
+	now /= g.unitMillis
+
+	if uint64(now) > g.timestampMask {
+		return 0, g.overflowErr
+	}
+	if g.warnFunc != nil && uint64(now) >= g.warnTimestamp && g.warned.CompareAndSwap(false, true) {
+		g.warnFunc(float64(now)/float64(g.timestampMask), g.layout.Exhaustion())
+	}
 
 	for {
 		currentID := g.currentID.Load()
 		newCurrentID := currentID
-		lastTime := currentID >> timeShift
+		lastTime := currentID >> g.sequenceBits
 		sequence := currentID & g.sequenceMask
 		switch {
 		case lastTime < uint64(now):
 			lastTime = uint64(now)
-			newCurrentID = lastTime << timeShift
+			newCurrentID = lastTime << g.sequenceBits
 		case sequence == g.sequenceMask:
 			if !g.drift {
 				return 0, ErrOutOfSequence
 			}
-			if lastTime-uint64(now) >= uint64(g.duration.Milliseconds()) {
+			if lastTime-uint64(now) >= g.driftTicks {
 				return 0, ErrOutOfSequence
 			}
-			newCurrentID = (lastTime + 1) << timeShift
+			newCurrentID = (lastTime + 1) << g.sequenceBits
 		default:
 			newCurrentID++
 		}
-		newCurrentID = newCurrentID | (g.machineID << g.machineIDShift)
+		if newCurrentID>>g.sequenceBits > g.timestampMask {
+			return 0, g.overflowErr
+		}
 		if g.currentID.CompareAndSwap(currentID, newCurrentID) {
-			return ID(newCurrentID), nil
+			timestamp := newCurrentID >> g.sequenceBits
+			sequence = newCurrentID & g.sequenceMask
+			return ID(timestamp<<g.timestampShift | g.machineIDPart | sequence<<g.sequenceShift | extra), nil
 		}
 	}
 }
 
 // BlockingNextID generates a new snowflake ID, blocking until the next ID can be generated
 func (g *Generator) BlockingNextID(ctx context.Context) (ID, error) {
-	id, err := g.NextID()
+	return g.blockingNextID(ctx, 0)
+}
+
+// BlockingNextIDWithFields generates a new snowflake ID with the values of the extra fields of the layout, blocking
+// until the next ID can be generated
+func (g *Generator) BlockingNextIDWithFields(ctx context.Context, values ...uint64) (ID, error) {
+	extra, err := g.layout.Extra(values...)
+	if err != nil {
+		return 0, err
+	}
+	return g.blockingNextID(ctx, extra)
+}
+
+func (g *Generator) blockingNextID(ctx context.Context, extra uint64) (ID, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	id, err := g.nextID(ctx, extra)
 	for errors.Is(err, ErrOutOfSequence) {
-		if ctx != nil && ctx.Err() != nil {
+		if ctx.Err() != nil {
 			return 0, ctx.Err()
 		}
 		g.sleepFunc()
-		id, err = g.NextID()
+		id, err = g.nextID(ctx, extra)
+	}
+	return id, err
+}
+
+// WithLayout sets the layout of the IDs of the generator, options that change the layout apply on top of it when they
+// follow this option
+func WithLayout(layout Layout) Option {
+	return func(generator *Generator) {
+		generator.layout = layout
 	}
-	return id, nil
 }
 
 // WithMachineIDBits sets the number of bits to use for the machine ID
+// The sequence gives its bits to or takes them from the machine ID, so the layout keeps its width
 func WithMachineIDBits(size uint64) Option {
 	return func(generator *Generator) {
-		generator.machineIDBits = size
+		generator.layout.SequenceBits = remainingBits(generator.layout.MachineIDBits+generator.layout.SequenceBits, size)
+		generator.layout.MachineIDBits = size
+	}
+}
+
+// remainingBits returns the bits of width that used does not take, or 0 if it takes them all
+func remainingBits(width, used uint64) uint64 {
+	if used < width {
+		return width - used
+	}
+	return 0
+}
+
+// WithTimestampBits sets the number of bits to use for the timestamp
+// The machine ID and sequence bits are not changed, all fields must fit in 64 bits
+func WithTimestampBits(size uint64) Option {
+	return func(generator *Generator) {
+		generator.layout.TimestampBits = size
+	}
+}
+
+// WithBitOrder sets the order of the machine ID and sequence fields below the timestamp
+func WithBitOrder(order BitOrder) Option {
+	return func(generator *Generator) {
+		generator.layout.Order = order
+	}
+}
+
+// WithTimeUnit sets the duration of one tick of the timestamp, it must be a multiple of a millisecond
+// A larger unit extends the lifespan of the generator, but fewer IDs can be generated per unit of time
+func WithTimeUnit(unit time.Duration) Option {
+	return func(generator *Generator) {
+		generator.layout.Unit = unit
+	}
+}
+
+// WithInt64Safe keeps the sign bit of the IDs clear, so they fit in signed 64-bit integers such as Postgres bigint and
+// Java long. A layout that uses all 64 bits loses the top bit of its timestamp, which halves the lifespan of the
+// generator. NextID returns ErrInt64Overflow when the timestamp no longer fits.
+func WithInt64Safe() Option {
+	return func(generator *Generator) {
+		generator.int64Safe = true
+	}
+}
+
+// WithJavaScriptSafe keeps the IDs at or below MaxSafeInteger, so they are exact JavaScript numbers.
+// The layout must have at most 53 bits, such as JavaScriptLayout, otherwise NewGenerator returns ErrUnsafeInteger.
+// NextID returns ErrSafeIntegerOverflow when the timestamp no longer fits.
+func WithJavaScriptSafe() Option {
+	return func(generator *Generator) {
+		generator.javaScriptSafe = true
+	}
+}
+
+// WithNodeFields divides the machine ID into named fields, such as a datacenter and a worker, from most to least
+// significant. The machine ID bits become the sum of the bits of the fields, and the sequence gets the bits that remain
+// after the timestamp and the machine ID. Use WithNodeID to set the values of the fields.
+func WithNodeFields(fields ...Field) Option {
+	return func(generator *Generator) {
+		if len(fields) > MaxNodeFields {
+			generator.optionErr = fmt.Errorf("%w: %d fields, the maximum is %d", ErrInvalidNodeFields, len(fields),
+				MaxNodeFields)
+			return
+		}
+		generator.layout.NodeFields = [MaxNodeFields]Field{}
+		copy(generator.layout.NodeFields[:], fields)
+		WithMachineIDBits(fieldBits(fields))(generator)
+	}
+}
+
+// WithExtraFields embeds named fields, such as an entity type or a shard, below the sequence of the IDs, from most to
+// least significant. The fields take bits from the sequence, so the layout keeps its width. Follow this option with
+// WithMachineIDBits to take bits from the machine ID instead. Use NextIDWithFields to set the values of the fields.
+func WithExtraFields(fields ...Field) Option {
+	return func(generator *Generator) {
+		if len(fields) > MaxExtraFields {
+			generator.optionErr = fmt.Errorf("%w: %d fields, the maximum is %d", ErrInvalidExtraFields, len(fields),
+				MaxExtraFields)
+			return
+		}
+		width := generator.layout.SequenceBits + generator.layout.extraBits()
+		generator.layout.ExtraFields = [MaxExtraFields]Field{}
+		copy(generator.layout.ExtraFields[:], fields)
+		generator.layout.SequenceBits = remainingBits(width, generator.layout.extraBits())
+	}
+}
+
+// WithNodeID sets the machine ID from the values of the node fields, in the order of the fields.
+// NewGenerator returns an error if a value does not fit in its field, the machineID argument is ignored.
+func WithNodeID(values ...uint64) Option {
+	return func(generator *Generator) {
+		generator.nodeValues = append([]uint64{}, values...)
+	}
+}
+
+// WithExhaustionWarning calls fn once when the generator has used the threshold fraction of its timestamp range, for
+// example 0.9 for 90%. It is called by NextID, so it should return quickly.
+func WithExhaustionWarning(threshold float64, fn ExhaustionFunc) Option {
+	return func(generator *Generator) {
+		if !(threshold > 0 && threshold <= 1) {
+			generator.optionErr = fmt.Errorf("%w: %v", ErrInvalidThreshold, threshold)
+			return
+		}
+		generator.warnThreshold = threshold
+		generator.warnFunc = fn
+	}
+}
+
+// WithClockRollback sets the policy for a clock that moves backwards, for example after an NTP step.
+// The tolerance is the longest that the RollbackWait policy waits for the clock to catch up, NextID blocks while it
+// waits and BlockingNextID stops waiting when its context is canceled.
+func WithClockRollback(policy RollbackPolicy, tolerance time.Duration) Option {
+	return func(generator *Generator) {
+		generator.rollbackPolicy = policy
+		generator.rollbackTolerance = tolerance
+	}
+}
+
+// WithMonotonicClock makes the generator read the time from a MonotonicClock with the slew rate, so steps of the wall
+// clock do not affect the IDs. See NewMonotonicClock for the slew rate.
+func WithMonotonicClock(slew float64) Option {
+	return func(generator *Generator) {
+		clock, err := NewMonotonicClock(slew)
+		if err != nil {
+			generator.optionErr = err
+			return
+		}
+		generator.timeFunc = clock.Now
+		generator.timeSource = true
 	}
 }
 
 // WithEpoch sets the epoch for the generator
 func WithEpoch(epoch time.Time) Option {
 	return func(generator *Generator) {
-		generator.epoch = epoch.UnixMilli()
+		generator.layout.Epoch = epoch
 	}
 }
 
 // WithDrift enables drift to continue generating IDs when the sequence overflows
 // This allows the generator to generate IDs for times in the future
+// The duration is rounded up to whole ticks of the time unit
 // This increases performance but may generate IDs out of sequence
 // This also sleeps for the duration in this constructor, to prevent ID collisions on a restart of the application.
 func WithDrift(duration time.Duration) Option {
@@ -180,6 +462,7 @@ func WithDrift(duration time.Duration) Option {
 
 // WithDriftNoWait enables drift to continue generating IDs when the sequence overflows
 // This allows the generator to generate IDs for times in the future
+// The duration is rounded up to whole ticks of the time unit
 // This increases performance but may generate IDs out of sequence
 // This does not sleep for the duration in this constructor, to prevent ID collisions on a restart of the application.
 // WARNING: This may cause ID collisions on a restart of the application. Use WithDrift instead.
@@ -191,9 +474,29 @@ func WithDriftNoWait(duration time.Duration) Option {
 }
 
 // WithExactSleep sets the sleep function to sleep until the next millisecond
-// This implements a busy wait loop to sleep until the next millisecond
+// This implements a busy wait loop to sleep until the next millisecond, it is WithSleepStrategy(SpinSleep)
 func WithExactSleep() Option {
+	return WithSleepStrategy(SpinSleep)
+}
+
+// WithSleepStrategy sets how BlockingNextID waits for the next tick when the sequence is exhausted.
+// The default strategy is time.Sleep, a nil strategy keeps it.
+func WithSleepStrategy(strategy SleepStrategy) Option {
 	return func(generator *Generator) {
-		generator.sleepFunc = exactSleepFunc
+		if strategy != nil {
+			generator.sleepStrategy = strategy
+		}
+	}
+}
+
+// WithTimeSource sets the function that the generator reads the time from, for example a fake clock in tests.
+// BlockingNextID then waits for the ticks of the time source, with millisecond precision. A nil time source keeps the
+// wall clock.
+func WithTimeSource(timeFunc TimeFunc) Option {
+	return func(generator *Generator) {
+		if timeFunc != nil {
+			generator.timeFunc = timeFunc
+			generator.timeSource = true
+		}
 	}
 }
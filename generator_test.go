@@ -399,3 +399,178 @@ func TestNewGenerator_NextId_WithDrif2ms_Returns3x(t *testing.T) {
 		t.Errorf("expected %v ids, got %v", maxCount, count)
 	}
 }
+
+// TestWithInt64Safe tests that an int64 safe generator keeps the sign bit of the IDs clear
+func TestWithInt64Safe(t *testing.T) {
+	generator, err := NewGenerator(1023, WithEpoch(time.UnixMilli(0)), WithInt64Safe(), WithDriftNoWait(time.Second))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if bits := generator.Layout().TimestampBits; bits != 41 {
+		t.Errorf("expected 41 timestamp bits, got %v", bits)
+	}
+
+	generator.timeFunc = func() uint64 {
+		return 1<<41 - 1
+	}
+	var id ID
+	for id, err = generator.NextID(); err == nil; id, err = generator.NextID() {
+		if id.Int64() < 0 {
+			t.Errorf("expected a positive int64, got %v", id.Int64())
+			return
+		}
+		if decoded := generator.DecodeID(id); decoded.Timestamp != 1<<41-1 || decoded.MachineID != 1023 {
+			t.Errorf("got %v, want timestamp %v and machine ID 1023", decoded, uint64(1<<41-1))
+			return
+		}
+	}
+	if !errors.Is(err, ErrInt64Overflow) {
+		t.Errorf("expected ErrInt64Overflow when drifting past the timestamp bits, got %v", err)
+	}
+
+	generator.timeFunc = func() uint64 {
+		return 1 << 41
+	}
+	if _, err = generator.NextID(); !errors.Is(err, ErrInt64Overflow) {
+		t.Errorf("expected ErrInt64Overflow, got %v", err)
+	}
+	if id, err = generator.BlockingNextID(context.Background()); id != 0 || !errors.Is(err, ErrInt64Overflow) {
+		t.Errorf("expected ErrInt64Overflow from BlockingNextID, got %v and %v", id, err)
+	}
+
+	generator, err = NewGenerator(1, WithLayout(TwitterLayout), WithInt64Safe())
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if generator.Layout() != TwitterLayout {
+		t.Errorf("expected the 63 bit Twitter layout to be unchanged, got %v", generator.Layout())
+	}
+}
+
+// TestWithJavaScriptSafe tests that a JavaScript safe generator never exceeds MaxSafeInteger
+func TestWithJavaScriptSafe(t *testing.T) {
+	generator, err := NewGenerator(31, WithLayout(JavaScriptLayout), WithEpoch(time.UnixMilli(0)), WithJavaScriptSafe(),
+		WithDriftNoWait(time.Second))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	generator.timeFunc = func() uint64 {
+		return (1<<38 - 1) * 10
+	}
+	var id, last ID
+	for id, err = generator.NextID(); err == nil; id, err = generator.NextID() {
+		if id > MaxSafeInteger {
+			t.Errorf("expected at most %v, got %v", uint64(MaxSafeInteger), uint64(id))
+			return
+		}
+		last = id
+	}
+	if !errors.Is(err, ErrSafeIntegerOverflow) || !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("expected ErrSafeIntegerOverflow when drifting past the timestamp bits, got %v", err)
+	}
+	if last != MaxSafeInteger {
+		t.Errorf("expected the last ID to be %v, got %v", uint64(MaxSafeInteger), uint64(last))
+	}
+	if decoded := generator.DecodeID(last); decoded.Timestamp != 1<<38-1 || decoded.MachineID != 31 || decoded.Sequence != 1023 {
+		t.Errorf("got %v", decoded)
+	}
+	for _, c := range []Codec{Influx64, Base58Short, Decimal, LowerHex} {
+		if got, err := Parse(c, last.FormatWith(c)); err != nil || got != last {
+			t.Errorf("%v round trip got %v, %v, want %v", c.Name(), uint64(got), err, uint64(last))
+		}
+	}
+
+	generator.timeFunc = func() uint64 {
+		return 1 << 38 * 10
+	}
+	if _, err = generator.NextID(); !errors.Is(err, ErrSafeIntegerOverflow) || !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("expected ErrSafeIntegerOverflow, got %v", err)
+	}
+
+	if _, err = NewGenerator(1, WithJavaScriptSafe()); !errors.Is(err, ErrUnsafeInteger) {
+		t.Errorf("expected ErrUnsafeInteger for the 64 bit default layout, got %v", err)
+	}
+}
+
+// TestGenerator_NextID_TimestampOverflow tests that the generator returns an error instead of wrapping around
+func TestGenerator_NextID_TimestampOverflow(t *testing.T) {
+	generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithDriftNoWait(time.Second))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if want := time.UnixMilli(1 << 42).UTC(); !generator.Exhaustion().Equal(want) {
+		t.Errorf("expected exhaustion at %v, got %v", want, generator.Exhaustion())
+	}
+
+	generator.timeFunc = func() uint64 {
+		return 1<<42 - 1
+	}
+	for _, err = generator.NextID(); err == nil; _, err = generator.NextID() {
+	}
+	if !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("expected ErrTimestampOverflow when drifting past the timestamp bits, got %v", err)
+	}
+
+	generator.timeFunc = func() uint64 {
+		return 1 << 42
+	}
+	if _, err = generator.NextID(); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("expected ErrTimestampOverflow, got %v", err)
+	}
+	if _, err = generator.BlockingNextID(context.Background()); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("expected ErrTimestampOverflow, got %v", err)
+	}
+	if !errors.Is(ErrInt64Overflow, ErrTimestampOverflow) {
+		t.Errorf("expected ErrInt64Overflow to wrap ErrTimestampOverflow")
+	}
+}
+
+// TestWithExhaustionWarning tests that the exhaustion warning fires once when the threshold is passed
+func TestWithExhaustionWarning(t *testing.T) {
+	var calls int
+	var usage float64
+	var exhaustion time.Time
+	generator, err := NewGenerator(1, WithLayout(SonyflakeLayout), WithExhaustionWarning(0.5, func(u float64, e time.Time) {
+		calls++
+		usage, exhaustion = u, e
+	}))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	now := uint64(SonyflakeLayout.Epoch.UnixMilli())
+	generator.timeFunc = func() uint64 {
+		return now
+	}
+	if _, err = generator.NextID(); err != nil || calls != 0 {
+		t.Errorf("expected no error and no warning, got %v and %v calls", err, calls)
+	}
+
+	now += (1 << 38) * 10
+	for i := 0; i < 3; i++ {
+		if _, err = generator.NextID(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 warning, got %v", calls)
+	}
+	if usage < 0.5 || usage > 0.51 {
+		t.Errorf("expected a usage of 0.5, got %v", usage)
+	}
+	if !exhaustion.Equal(generator.Exhaustion()) || exhaustion.Year() != 2188 {
+		t.Errorf("expected exhaustion in 2188 at %v, got %v", generator.Exhaustion(), exhaustion)
+	}
+
+	for _, threshold := range []float64{0, -1, 1.5} {
+		if _, err = NewGenerator(1, WithExhaustionWarning(threshold, nil)); !errors.Is(err, ErrInvalidThreshold) {
+			t.Errorf("expected ErrInvalidThreshold for %v, got %v", threshold, err)
+		}
+	}
+}
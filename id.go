@@ -1,9 +1,14 @@
 package snowflake
 
 import (
+	"github.com/crosscode-nl/snowflake/internal/codecs/base58"
+	"github.com/crosscode-nl/snowflake/internal/codecs/base62"
 	"github.com/crosscode-nl/snowflake/internal/codecs/base64"
 	"github.com/crosscode-nl/snowflake/internal/codecs/base64/influx"
+	"github.com/crosscode-nl/snowflake/internal/codecs/crockford"
+	"github.com/crosscode-nl/snowflake/internal/codecs/decimal"
 	"github.com/crosscode-nl/snowflake/internal/codecs/hex"
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
 )
 
 // ID is a snowflake ID
@@ -17,91 +22,283 @@ func (id ID) String() string {
 	return id.Influx64String()
 }
 
+// Int64 returns the ID as a signed 64-bit integer, for consumers such as Java and SQL that use signed longs.
+// The bits of the ID are reinterpreted, so IDs with the top bit set, those above math.MaxInt64, become negative.
+// IDFromInt64 reverses the conversion.
+func (id ID) Int64() int64 {
+	return int64(id)
+}
+
+// DecimalString returns a decimal string of the snowflake ID without leading zeros
+func (id ID) DecimalString() string {
+	var b [20]byte
+	decimal.Encode(&b, uint64(id))
+	return string(b[decimal.Shortest(&b):])
+}
+
+// PaddedDecimalString returns a decimal string of the snowflake ID.
+// The string is padded with zeros to a fixed length of 20 characters and sorts in the same order as the IDs.
+func (id ID) PaddedDecimalString() string {
+	var b [20]byte
+	decimal.Encode(&b, uint64(id))
+	return string(b[:])
+}
+
 // LowerHexString returns a lower case hex string of the snowflake ID
 func (id ID) LowerHexString() string {
 	var b [16]byte
-	hex.Encode(&b, uint64(id), hex.Lower)
+	hex.Encode(&b, uint64(id), &hex.LowerDigits)
 	return string(b[:])
 }
 
 // UpperHexString returns an upper case hex string of the snowflake ID
 func (id ID) UpperHexString() string {
 	var b [16]byte
-	hex.Encode(&b, uint64(id), hex.Upper)
+	hex.Encode(&b, uint64(id), &hex.UpperDigits)
 	return string(b[:])
 }
 
 // Base64String returns a base64 string of the snowflake ID
 func (id ID) Base64String() string {
 	var b [11]byte
-	base64.Encode(&b, uint64(id), base64.Alphabet)
+	base64.Encode(&b, uint64(id), &base64.StdDigits)
 	return string(b[:])
 }
 
 // Base64StringCustom returns a custom base64 string of the snowflake ID
 func (id ID) Base64StringCustom(alphabet Alphabet) string {
 	var b [11]byte
-	base64.Encode(&b, uint64(id), alphabet)
+	digits := alphabet()
+	base64.Encode(&b, uint64(id), &digits)
 	return string(b[:])
 }
 
 // Influx64String returns an Influx style base64 string of the snowflake ID
 func (id ID) Influx64String() string {
 	var b [11]byte
-	influx.Encode(&b, uint64(id), influx.Alphabet)
+	influx.Encode(&b, uint64(id), &influx.Digits)
 	return string(b[:])
 }
 
 // Influx64StringCustom returns a custom Influx style base64 string of the snowflake ID
 func (id ID) Influx64StringCustom(alphabet Alphabet) string {
 	var b [11]byte
-	influx.Encode(&b, uint64(id), alphabet)
+	digits := alphabet()
+	influx.Encode(&b, uint64(id), &digits)
+	return string(b[:])
+}
+
+// CrockfordString returns a Crockford base32 string of the snowflake ID.
+// The string has a fixed length of 13 characters and sorts in the same order as the IDs.
+func (id ID) CrockfordString() string {
+	var b [13]byte
+	crockford.Encode(&b, uint64(id), &crockford.Digits)
+	return string(b[:])
+}
+
+// Base58String returns a base58 string of the snowflake ID with the Bitcoin alphabet.
+// The string is padded to a fixed length of 11 characters and sorts in the same order as the IDs.
+func (id ID) Base58String() string {
+	var b [11]byte
+	base58.Encode(&b, uint64(id), &base58.Digits)
+	return string(b[:])
+}
+
+// Base58ShortString returns the shortest base58 string of the snowflake ID with the Bitcoin alphabet
+func (id ID) Base58ShortString() string {
+	var b [11]byte
+	base58.Encode(&b, uint64(id), &base58.Digits)
+	return string(b[base58.Shortest(&b, &base58.Digits):])
+}
+
+// Base62String returns a base62 string of the snowflake ID.
+// The string is padded to a fixed length of 11 characters and sorts in the same order as the IDs.
+func (id ID) Base62String() string {
+	var b [11]byte
+	base62.Encode(&b, uint64(id), &base62.Digits)
 	return string(b[:])
 }
 
+// Base62ShortString returns the shortest base62 string of the snowflake ID
+func (id ID) Base62ShortString() string {
+	var b [11]byte
+	base62.Encode(&b, uint64(id), &base62.Digits)
+	return string(b[base62.Shortest(&b, &base62.Digits):])
+}
+
+// AppendDecimal appends a decimal string of the snowflake ID without leading zeros to dst and returns the extended
+// buffer
+func (id ID) AppendDecimal(dst []byte) []byte {
+	var b [20]byte
+	decimal.Encode(&b, uint64(id))
+	return append(dst, b[decimal.Shortest(&b):]...)
+}
+
+// AppendPaddedDecimal appends a zero padded decimal string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendPaddedDecimal(dst []byte) []byte {
+	var b [20]byte
+	decimal.Encode(&b, uint64(id))
+	return append(dst, b[:]...)
+}
+
+// AppendLowerHex appends a lower case hex string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendLowerHex(dst []byte) []byte {
+	var b [16]byte
+	hex.Encode(&b, uint64(id), &hex.LowerDigits)
+	return append(dst, b[:]...)
+}
+
+// AppendUpperHex appends an upper case hex string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendUpperHex(dst []byte) []byte {
+	var b [16]byte
+	hex.Encode(&b, uint64(id), &hex.UpperDigits)
+	return append(dst, b[:]...)
+}
+
+// AppendBase64 appends a base64 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase64(dst []byte) []byte {
+	var b [11]byte
+	base64.Encode(&b, uint64(id), &base64.StdDigits)
+	return append(dst, b[:]...)
+}
+
+// AppendBase64Custom appends a custom base64 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase64Custom(dst []byte, alphabet Alphabet) []byte {
+	var b [11]byte
+	digits := alphabet()
+	base64.Encode(&b, uint64(id), &digits)
+	return append(dst, b[:]...)
+}
+
+// AppendInflux64 appends an Influx style base64 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendInflux64(dst []byte) []byte {
+	var b [11]byte
+	influx.Encode(&b, uint64(id), &influx.Digits)
+	return append(dst, b[:]...)
+}
+
+// AppendInflux64Custom appends a custom Influx style base64 string of the snowflake ID to dst and returns the extended
+// buffer
+func (id ID) AppendInflux64Custom(dst []byte, alphabet Alphabet) []byte {
+	var b [11]byte
+	digits := alphabet()
+	influx.Encode(&b, uint64(id), &digits)
+	return append(dst, b[:]...)
+}
+
+// AppendCrockford appends a Crockford base32 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendCrockford(dst []byte) []byte {
+	var b [13]byte
+	crockford.Encode(&b, uint64(id), &crockford.Digits)
+	return append(dst, b[:]...)
+}
+
+// AppendBase58 appends a padded base58 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase58(dst []byte) []byte {
+	var b [11]byte
+	base58.Encode(&b, uint64(id), &base58.Digits)
+	return append(dst, b[:]...)
+}
+
+// AppendBase58Short appends the shortest base58 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase58Short(dst []byte) []byte {
+	var b [11]byte
+	base58.Encode(&b, uint64(id), &base58.Digits)
+	return append(dst, b[base58.Shortest(&b, &base58.Digits):]...)
+}
+
+// AppendBase62 appends a padded base62 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase62(dst []byte) []byte {
+	var b [11]byte
+	base62.Encode(&b, uint64(id), &base62.Digits)
+	return append(dst, b[:]...)
+}
+
+// AppendBase62Short appends the shortest base62 string of the snowflake ID to dst and returns the extended buffer
+func (id ID) AppendBase62Short(dst []byte) []byte {
+	var b [11]byte
+	base62.Encode(&b, uint64(id), &base62.Digits)
+	return append(dst, b[base62.Shortest(&b, &base62.Digits):]...)
+}
+
 // IDFromString returns a snowflake ID from a string
 func IDFromString(s string) ID {
 	return IDFromInflux64String(s)
 }
 
+// IDFromInt64 returns a snowflake ID from a signed 64-bit integer, the reverse of Int64.
+// The bits of the integer are reinterpreted, so negative numbers become IDs with the top bit set.
+func IDFromInt64(n int64) ID {
+	return ID(n)
+}
+
+// IDFromDecimalString returns a snowflake ID from a decimal string with or without leading zeros
+func IDFromDecimalString(s string) ID {
+	n, _ := decimal.Decode([]byte(s), decimal.Table)
+	return ID(n)
+}
+
 // IDFromLowerHexString returns a snowflake ID from a lower case hex string
 func IDFromLowerHexString(s string) ID {
 	var b [16]byte
 	copy(b[:], s)
-	return ID(hex.Decode(&b, hex.LowerLookup))
+	return ID(hex.Decode(&b, hex.LowerTable))
 }
 
 // IDFromUpperHexString returns a snowflake ID from an upper case hex string
 func IDFromUpperHexString(s string) ID {
 	var b [16]byte
 	copy(b[:], s)
-	return ID(hex.Decode(&b, hex.UpperLookup))
+	return ID(hex.Decode(&b, hex.UpperTable))
 }
 
 // IDFromBase64String returns a snowflake ID from a base64 string
 func IDFromBase64String(s string) ID {
 	var b [11]byte
 	copy(b[:], s)
-	return ID(base64.Decode(&b, base64.AlphabetLookup))
+	return ID(base64.Decode(&b, base64.StdTable))
 }
 
-// IDFromBase64StringCustom returns a snowflake ID from a custom base64 string
+// IDFromBase64StringCustom returns a snowflake ID from a custom base64 string.
+// Each call builds the lookup map, use Parse with a codec from NewBase64Codec to parse many IDs.
 func IDFromBase64StringCustom(s string, alphabetLookup AlphabetLookup) ID {
 	var b [11]byte
 	copy(b[:], s)
-	return ID(base64.Decode(&b, alphabetLookup))
+	var t lookup.Table
+	return ID(base64.Decode(&b, customTable(&t, b[:], alphabetLookup)))
 }
 
 // IDFromInflux64String returns a snowflake ID from an Influx style base64 string
 func IDFromInflux64String(s string) ID {
 	var b [11]byte
 	copy(b[:], s)
-	return ID(influx.Decode(&b, influx.AlphabetLookup))
+	return ID(influx.Decode(&b, influx.Table))
 }
 
-// IDFromInflux64StringCustom returns a snowflake ID from a custom Influx style base64 string
+// IDFromInflux64StringCustom returns a snowflake ID from a custom Influx style base64 string.
+// Each call builds the lookup map, use Parse with a codec from NewInflux64Codec to parse many IDs.
 func IDFromInflux64StringCustom(s string, alphabetLookup AlphabetLookup) ID {
 	var b [11]byte
 	copy(b[:], s)
-	return ID(influx.Decode(&b, alphabetLookup))
+	var t lookup.Table
+	return ID(influx.Decode(&b, customTable(&t, b[:], alphabetLookup)))
+}
+
+// IDFromCrockfordString returns a snowflake ID from a Crockford base32 string, decoding is case-insensitive
+func IDFromCrockfordString(s string) ID {
+	var b [13]byte
+	copy(b[:], s)
+	return ID(crockford.Decode(&b, crockford.Table))
+}
+
+// IDFromBase58String returns a snowflake ID from a padded or short base58 string
+func IDFromBase58String(s string) ID {
+	n, _ := base58.Decode([]byte(s), base58.Table)
+	return ID(n)
+}
+
+// IDFromBase62String returns a snowflake ID from a padded or short base62 string
+func IDFromBase62String(s string) ID {
+	n, _ := base62.Decode([]byte(s), base62.Table)
+	return ID(n)
 }
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"github.com/crosscode-nl/snowflake/internal/codecs/base64"
 	"math"
+	"math/rand"
+	"sort"
+	"strconv"
 	"testing"
 )
 
@@ -39,6 +42,182 @@ func BenchmarkID_UpperHexString(b *testing.B) {
 	}
 }
 
+// BenchmarkIDFromBase64String benchmarks the IDFromBase64String function
+func BenchmarkIDFromBase64String(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IDFromBase64String("UjQCCg+wAKA")
+	}
+}
+
+// BenchmarkIDFromInflux64String benchmarks the IDFromInflux64String function
+func BenchmarkIDFromInflux64String(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IDFromInflux64String("A00h0xA0ZHI")
+	}
+}
+
+// BenchmarkIDFromLowerHexString benchmarks the IDFromLowerHexString function
+func BenchmarkIDFromLowerHexString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IDFromLowerHexString("a000b00f0a023452")
+	}
+}
+
+// BenchmarkIDFromUpperHexString benchmarks the IDFromUpperHexString function
+func BenchmarkIDFromUpperHexString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IDFromUpperHexString("A000B00F0A023452")
+	}
+}
+
+// BenchmarkParseInflux64String benchmarks the ParseInflux64String function
+func BenchmarkParseInflux64String(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseInflux64String("A00h0xA0ZHI")
+	}
+}
+
+// BenchmarkID_AppendBase64 benchmarks the AppendBase64 method of the ID type
+func BenchmarkID_AppendBase64(b *testing.B) {
+	id := ID(0x0000000000000001)
+	buf := make([]byte, 0, 11)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendBase64(buf[:0])
+	}
+}
+
+// BenchmarkID_AppendInflux64 benchmarks the AppendInflux64 method of the ID type
+func BenchmarkID_AppendInflux64(b *testing.B) {
+	id := ID(0x0000000000000001)
+	buf := make([]byte, 0, 11)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendInflux64(buf[:0])
+	}
+}
+
+// BenchmarkID_AppendLowerHex benchmarks the AppendLowerHex method of the ID type
+func BenchmarkID_AppendLowerHex(b *testing.B) {
+	id := ID(0x0000000000000001)
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendLowerHex(buf[:0])
+	}
+}
+
+// BenchmarkParseInflux64Bytes benchmarks the ParseInflux64Bytes function
+func BenchmarkParseInflux64Bytes(b *testing.B) {
+	s := []byte("A00h0xA0ZHI")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseInflux64Bytes(s)
+	}
+}
+
+// BenchmarkParseBase64Bytes benchmarks the ParseBase64Bytes function
+func BenchmarkParseBase64Bytes(b *testing.B) {
+	s := []byte("UjQCCg+wAKA")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBase64Bytes(s)
+	}
+}
+
+// BenchmarkParseBase64BytesCustom benchmarks the ParseBase64BytesCustom function, the lookup map is built on each call
+func BenchmarkParseBase64BytesCustom(b *testing.B) {
+	s := []byte("UjQCCg+wAKA")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBase64BytesCustom(s, base64.AlphabetLookup)
+	}
+}
+
+// BenchmarkParseLowerHexBytes benchmarks the ParseLowerHexBytes function
+func BenchmarkParseLowerHexBytes(b *testing.B) {
+	s := []byte("a000b00f0a023452")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseLowerHexBytes(s)
+	}
+}
+
+// TestID_Append_ZeroAllocations tests that the Append methods and the byte parsers do not allocate
+func TestID_Append_ZeroAllocations(t *testing.T) {
+	id := ID(0xA000B00F0A023452)
+	buf := make([]byte, 0, 64)
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "AppendLowerHex", f: func() { buf = id.AppendLowerHex(buf[:0]) }},
+		{name: "AppendUpperHex", f: func() { buf = id.AppendUpperHex(buf[:0]) }},
+		{name: "AppendBase64", f: func() { buf = id.AppendBase64(buf[:0]) }},
+		{name: "AppendBase64Custom", f: func() { buf = id.AppendBase64Custom(buf[:0], base64.UrlAlphabet) }},
+		{name: "AppendInflux64", f: func() { buf = id.AppendInflux64(buf[:0]) }},
+		{name: "AppendInflux64Custom", f: func() { buf = id.AppendInflux64Custom(buf[:0], base64.UrlAlphabet) }},
+		{name: "AppendCrockford", f: func() { buf = id.AppendCrockford(buf[:0]) }},
+		{name: "AppendBase58", f: func() { buf = id.AppendBase58(buf[:0]) }},
+		{name: "AppendBase58Short", f: func() { buf = id.AppendBase58Short(buf[:0]) }},
+		{name: "AppendBase62", f: func() { buf = id.AppendBase62(buf[:0]) }},
+		{name: "AppendBase62Short", f: func() { buf = id.AppendBase62Short(buf[:0]) }},
+		{name: "AppendDecimal", f: func() { buf = id.AppendDecimal(buf[:0]) }},
+		{name: "AppendPaddedDecimal", f: func() { buf = id.AppendPaddedDecimal(buf[:0]) }},
+		{name: "AppendText", f: func() { buf, _ = id.AppendText(buf[:0]) }},
+		{name: "ParseLowerHexBytes", f: func() { _, _ = ParseLowerHexBytes([]byte("a000b00f0a023452")) }},
+		{name: "ParseUpperHexBytes", f: func() { _, _ = ParseUpperHexBytes([]byte("A000B00F0A023452")) }},
+		{name: "ParseBase64Bytes", f: func() { _, _ = ParseBase64Bytes([]byte("UjQCCg+wAKA")) }},
+		{name: "ParseInflux64Bytes", f: func() { _, _ = ParseInflux64Bytes([]byte("A00h0xA0ZHI")) }},
+		{name: "ParseInflux64String", f: func() { _, _ = ParseInflux64String("A00h0xA0ZHI") }},
+		{name: "ParseCrockfordBytes", f: func() { _, _ = ParseCrockfordBytes([]byte("A005G1W504D2J")) }},
+		{name: "ParseBase58Bytes", f: func() { _, _ = ParseBase58Bytes([]byte("TmE8u91MvdP")) }},
+		{name: "ParseBase58String", f: func() { _, _ = ParseBase58String("TmE8u91MvdP") }},
+		{name: "ParseBase62Bytes", f: func() { _, _ = ParseBase62Bytes([]byte("DjgoSJw8iZe")) }},
+		{name: "ParseBase62String", f: func() { _, _ = ParseBase62String("DjgoSJw8iZe") }},
+		{name: "ParseDecimalBytes", f: func() { _, _ = ParseDecimalBytes([]byte("11529408624707384402")) }},
+		{name: "ParseDecimalString", f: func() { _, _ = ParseDecimalString("11529408624707384402") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.f); allocs != 0 {
+				t.Errorf("expected 0 allocations, got %v", allocs)
+			}
+		})
+	}
+}
+
+// TestID_Append tests that the Append methods append the same encoding as the String methods
+func TestID_Append(t *testing.T) {
+	id := ID(0xA000B00F0A023452)
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{name: "AppendLowerHex", got: id.AppendLowerHex([]byte("x")), want: "x" + id.LowerHexString()},
+		{name: "AppendUpperHex", got: id.AppendUpperHex([]byte("x")), want: "x" + id.UpperHexString()},
+		{name: "AppendBase64", got: id.AppendBase64([]byte("x")), want: "x" + id.Base64String()},
+		{name: "AppendBase64Custom", got: id.AppendBase64Custom([]byte("x"), base64.MimeAlphabet), want: "x" + id.Base64StringCustom(base64.MimeAlphabet)},
+		{name: "AppendInflux64", got: id.AppendInflux64([]byte("x")), want: "x" + id.Influx64String()},
+		{name: "AppendInflux64Custom", got: id.AppendInflux64Custom([]byte("x"), base64.UrlAlphabet), want: "x" + id.Influx64StringCustom(base64.UrlAlphabet)},
+		{name: "AppendCrockford", got: id.AppendCrockford([]byte("x")), want: "x" + id.CrockfordString()},
+		{name: "AppendBase58", got: id.AppendBase58([]byte("x")), want: "x" + id.Base58String()},
+		{name: "AppendBase58Short", got: id.AppendBase58Short([]byte("x")), want: "x" + id.Base58ShortString()},
+		{name: "AppendBase62", got: id.AppendBase62([]byte("x")), want: "x" + id.Base62String()},
+		{name: "AppendBase62Short", got: id.AppendBase62Short([]byte("x")), want: "x" + id.Base62ShortString()},
+		{name: "AppendDecimal", got: id.AppendDecimal([]byte("x")), want: "x" + id.DecimalString()},
+		{name: "AppendPaddedDecimal", got: id.AppendPaddedDecimal([]byte("x")), want: "x" + id.PaddedDecimalString()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if string(tt.got) != tt.want {
+				t.Errorf("got %v, want %v", string(tt.got), tt.want)
+			}
+		})
+	}
+}
+
 // ExampleID_Base64String is an example of the ID Base64String method
 func ExampleID_Base64String() {
 	id := ID(0x0000000000000001)
@@ -249,3 +428,190 @@ func ExampleIDFromUpperHexString() {
 	// 11529408624707384402
 	// 18446744073709551615
 }
+
+// ExampleID_CrockfordString is an example of the ID CrockfordString method
+func ExampleID_CrockfordString() {
+	id := ID(0x0000000000000001)
+	fmt.Println(id.CrockfordString())
+	id = ID(0xA000B00F0A023452)
+	fmt.Println(id.CrockfordString())
+	id = ID(math.MaxUint64)
+	fmt.Println(id.CrockfordString())
+	// Output:
+	// 0000000000001
+	// A005G1W504D2J
+	// FZZZZZZZZZZZZ
+}
+
+// ExampleIDFromCrockfordString is an example of the IDFromCrockfordString function
+func ExampleIDFromCrockfordString() {
+	id := IDFromCrockfordString("0000000000001")
+	fmt.Println(uint64(id))
+	id = IDFromCrockfordString("a005g1w504d2j")
+	fmt.Println(uint64(id))
+	id = IDFromCrockfordString("FZZZZZZZZZZZZ")
+	fmt.Println(uint64(id))
+	// Output:
+	// 1
+	// 11529408624707384402
+	// 18446744073709551615
+}
+
+// TestID_Base58Base62_RoundTrip tests that the base58 and base62 encodings round trip over the full uint64 range
+func TestID_Base58Base62_RoundTrip(t *testing.T) {
+	ids := []ID{0, 1, 57, 58, 61, 62, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for i := 0; i < 64; i++ {
+		ids = append(ids, ID(1)<<i, ID(1)<<i-1)
+	}
+	for n := uint64(0); n < math.MaxUint64-0x00F0F0F0F0F0F0F0; n += 0x00F0F0F0F0F0F0F1 {
+		ids = append(ids, ID(n))
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		ids = append(ids, ID(r.Uint64()))
+	}
+
+	tests := []struct {
+		name   string
+		encode func(ID) string
+		parse  func(string) (ID, error)
+		decode func(string) ID
+	}{
+		{name: "Base58", encode: ID.Base58String, parse: ParseBase58String, decode: IDFromBase58String},
+		{name: "Base58Short", encode: ID.Base58ShortString, parse: ParseBase58String, decode: IDFromBase58String},
+		{name: "Base62", encode: ID.Base62String, parse: ParseBase62String, decode: IDFromBase62String},
+		{name: "Base62Short", encode: ID.Base62ShortString, parse: ParseBase62String, decode: IDFromBase62String},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, id := range ids {
+				s := tt.encode(id)
+				got, err := tt.parse(s)
+				if err != nil || got != id {
+					t.Fatalf("parse(%v) = %v, %v, want %v", s, uint64(got), err, uint64(id))
+				}
+				if got = tt.decode(s); got != id {
+					t.Fatalf("decode(%v) = %v, want %v", s, uint64(got), uint64(id))
+				}
+			}
+		})
+	}
+}
+
+// TestID_Base58Base62_SortOrder tests that the padded base58 and base62 strings sort in the same order as the IDs
+func TestID_Base58Base62_SortOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ids := []ID{0, 1, 57, 58, 61, 62, math.MaxUint64}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, ID(r.Uint64()>>(i%64)))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] == ids[i] {
+			continue
+		}
+		if a, b := ids[i-1].Base58String(), ids[i].Base58String(); a >= b {
+			t.Errorf("Base58String() %v >= %v for %v < %v", a, b, uint64(ids[i-1]), uint64(ids[i]))
+		}
+		if a, b := ids[i-1].Base62String(), ids[i].Base62String(); a >= b {
+			t.Errorf("Base62String() %v >= %v for %v < %v", a, b, uint64(ids[i-1]), uint64(ids[i]))
+		}
+	}
+}
+
+// ExampleID_Base58String is an example of the ID Base58String and Base58ShortString methods
+func ExampleID_Base58String() {
+	id := ID(0x0000000000000001)
+	fmt.Println(id.Base58String(), id.Base58ShortString())
+	id = ID(0xA000B00F0A023452)
+	fmt.Println(id.Base58String(), id.Base58ShortString())
+	id = ID(math.MaxUint64)
+	fmt.Println(id.Base58String(), id.Base58ShortString())
+	// Output:
+	// 11111111112 2
+	// TmE8u91MvdP TmE8u91MvdP
+	// jpXCZedGfVQ jpXCZedGfVQ
+}
+
+// ExampleID_Base62String is an example of the ID Base62String and Base62ShortString methods
+func ExampleID_Base62String() {
+	id := ID(0x0000000000000001)
+	fmt.Println(id.Base62String(), id.Base62ShortString())
+	id = ID(0xA000B00F0A023452)
+	fmt.Println(id.Base62String(), id.Base62ShortString())
+	id = ID(math.MaxUint64)
+	fmt.Println(id.Base62String(), id.Base62ShortString())
+	// Output:
+	// 00000000001 1
+	// DjgoSJw8iZe DjgoSJw8iZe
+	// LygHa16AHYF LygHa16AHYF
+}
+
+// TestID_Decimal tests the decimal strings against strconv and their sort order
+func TestID_Decimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ids := []ID{0, 1, 9, 10, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, ID(r.Uint64()>>(i%64)))
+	}
+	for _, id := range ids {
+		if got, want := id.DecimalString(), strconv.FormatUint(uint64(id), 10); got != want {
+			t.Errorf("DecimalString() = %v, want %v", got, want)
+		}
+		if got := IDFromDecimalString(id.PaddedDecimalString()); got != id {
+			t.Errorf("IDFromDecimalString(%v) = %v", id.PaddedDecimalString(), uint64(got))
+		}
+		if got, err := ParseDecimalString(id.DecimalString()); err != nil || got != id {
+			t.Errorf("ParseDecimalString(%v) = %v, %v", id.DecimalString(), uint64(got), err)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] != ids[i] && ids[i-1].PaddedDecimalString() >= ids[i].PaddedDecimalString() {
+			t.Errorf("PaddedDecimalString() does not sort %v before %v", uint64(ids[i-1]), uint64(ids[i]))
+		}
+	}
+}
+
+// TestID_Int64 tests the conversion between IDs and signed 64-bit integers
+func TestID_Int64(t *testing.T) {
+	tests := []struct {
+		id   ID
+		want int64
+	}{
+		{id: 0, want: 0},
+		{id: 1, want: 1},
+		{id: math.MaxInt64, want: math.MaxInt64},
+		{id: math.MaxInt64 + 1, want: math.MinInt64},
+		{id: math.MaxUint64, want: -1},
+	}
+	for _, tt := range tests {
+		if got := tt.id.Int64(); got != tt.want {
+			t.Errorf("ID(%v).Int64() = %v, want %v", uint64(tt.id), got, tt.want)
+		}
+		if got := IDFromInt64(tt.want); got != tt.id {
+			t.Errorf("IDFromInt64(%v) = %v, want %v", tt.want, uint64(got), uint64(tt.id))
+		}
+	}
+}
+
+// ExampleID_DecimalString is an example of the ID DecimalString and PaddedDecimalString methods
+func ExampleID_DecimalString() {
+	id := ID(42)
+	fmt.Println(id.DecimalString(), id.PaddedDecimalString())
+	id = ID(0xA000B00F0A023452)
+	fmt.Println(id.DecimalString(), id.PaddedDecimalString())
+	// Output:
+	// 42 00000000000000000042
+	// 11529408624707384402 11529408624707384402
+}
+
+// ExampleID_Int64 is an example of the ID Int64 method and the IDFromInt64 function
+func ExampleID_Int64() {
+	id := ID(0xA000B00F0A023452)
+	fmt.Println(id.Int64())
+	fmt.Println(uint64(IDFromInt64(-6917335449002167214)))
+	// Output:
+	// -6917335449002167214
+	// 11529408624707384402
+}
@@ -0,0 +1,67 @@
+package base58
+
+import (
+	"math/bits"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+// Alphabet returns the Bitcoin base58 alphabet, it is in ascending byte order, so padded strings preserve sort order
+func Alphabet() [58]byte {
+	return [58]byte{
+		'1', '2', '3', '4', '5', '6', '7', '8', '9', 'A',
+		'B', 'C', 'D', 'E', 'F', 'G', 'H', 'J', 'K', 'L',
+		'M', 'N', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W',
+		'X', 'Y', 'Z', 'a', 'b', 'c', 'd', 'e', 'f', 'g',
+		'h', 'i', 'j', 'k', 'm', 'n', 'o', 'p', 'q', 'r',
+		's', 't', 'u', 'v', 'w', 'x', 'y', 'z'}
+}
+
+func AlphabetLookup() map[byte]uint64 {
+	return map[byte]uint64{
+		'1': 0, '2': 1, '3': 2, '4': 3, '5': 4, '6': 5, '7': 6, '8': 7, '9': 8, 'A': 9,
+		'B': 10, 'C': 11, 'D': 12, 'E': 13, 'F': 14, 'G': 15, 'H': 16, 'J': 17, 'K': 18, 'L': 19,
+		'M': 20, 'N': 21, 'P': 22, 'Q': 23, 'R': 24, 'S': 25, 'T': 26, 'U': 27, 'V': 28, 'W': 29,
+		'X': 30, 'Y': 31, 'Z': 32, 'a': 33, 'b': 34, 'c': 35, 'd': 36, 'e': 37, 'f': 38, 'g': 39,
+		'h': 40, 'i': 41, 'j': 42, 'k': 43, 'm': 44, 'n': 45, 'o': 46, 'p': 47, 'q': 48, 'r': 49,
+		's': 50, 't': 51, 'u': 52, 'v': 53, 'w': 54, 'x': 55, 'y': 56, 'z': 57,
+	}
+}
+
+// The digits and reverse lookup table of the alphabet are precomputed once, they must not be modified
+var (
+	Digits = Alphabet()
+	Table  = lookup.New(Digits[:])
+)
+
+// Encode encodes a number into a base58 string of 11 characters, padded with the zero digit
+func Encode(s *[11]byte, n uint64, digits *[58]byte) {
+	for i := 10; i >= 0; i-- {
+		s[i], n = digits[n%58], n/58
+	}
+}
+
+// Shortest returns the position of the first significant digit of an encoded string, the shortest form of the number
+// starts at this position. The shortest form of zero is a single zero digit.
+func Shortest(s *[11]byte, digits *[58]byte) int {
+	i := 0
+	for i < 10 && s[i] == digits[0] {
+		i++
+	}
+	return i
+}
+
+// Decode decodes a base58 string of any length into a number, characters that are not in the lookup table decode as
+// zero. Returns the position of the character that overflows 64 bits, or -1 if the number fits.
+func Decode(s []byte, t *lookup.Table) (uint64, int) {
+	var n uint64
+	for i, c := range s {
+		hi, lo := bits.Mul64(n, 58)
+		lo, carry := bits.Add64(lo, t.Value(c), 0)
+		if hi|carry != 0 {
+			return 0, i
+		}
+		n = lo
+	}
+	return n, -1
+}
@@ -0,0 +1,69 @@
+package base62
+
+import (
+	"math/bits"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+// Alphabet returns the base62 alphabet, it is in ascending byte order, so padded strings preserve sort order
+func Alphabet() [62]byte {
+	return [62]byte{
+		'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+		'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J',
+		'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T',
+		'U', 'V', 'W', 'X', 'Y', 'Z', 'a', 'b', 'c', 'd',
+		'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n',
+		'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x',
+		'y', 'z'}
+}
+
+func AlphabetLookup() map[byte]uint64 {
+	return map[byte]uint64{
+		'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+		'A': 10, 'B': 11, 'C': 12, 'D': 13, 'E': 14, 'F': 15, 'G': 16, 'H': 17, 'I': 18, 'J': 19,
+		'K': 20, 'L': 21, 'M': 22, 'N': 23, 'O': 24, 'P': 25, 'Q': 26, 'R': 27, 'S': 28, 'T': 29,
+		'U': 30, 'V': 31, 'W': 32, 'X': 33, 'Y': 34, 'Z': 35, 'a': 36, 'b': 37, 'c': 38, 'd': 39,
+		'e': 40, 'f': 41, 'g': 42, 'h': 43, 'i': 44, 'j': 45, 'k': 46, 'l': 47, 'm': 48, 'n': 49,
+		'o': 50, 'p': 51, 'q': 52, 'r': 53, 's': 54, 't': 55, 'u': 56, 'v': 57, 'w': 58, 'x': 59,
+		'y': 60, 'z': 61,
+	}
+}
+
+// The digits and reverse lookup table of the alphabet are precomputed once, they must not be modified
+var (
+	Digits = Alphabet()
+	Table  = lookup.New(Digits[:])
+)
+
+// Encode encodes a number into a base62 string of 11 characters, padded with the zero digit
+func Encode(s *[11]byte, n uint64, digits *[62]byte) {
+	for i := 10; i >= 0; i-- {
+		s[i], n = digits[n%62], n/62
+	}
+}
+
+// Shortest returns the position of the first significant digit of an encoded string, the shortest form of the number
+// starts at this position. The shortest form of zero is a single zero digit.
+func Shortest(s *[11]byte, digits *[62]byte) int {
+	i := 0
+	for i < 10 && s[i] == digits[0] {
+		i++
+	}
+	return i
+}
+
+// Decode decodes a base62 string of any length into a number, characters that are not in the lookup table decode as
+// zero. Returns the position of the character that overflows 64 bits, or -1 if the number fits.
+func Decode(s []byte, t *lookup.Table) (uint64, int) {
+	var n uint64
+	for i, c := range s {
+		hi, lo := bits.Mul64(n, 62)
+		lo, carry := bits.Add64(lo, t.Value(c), 0)
+		if hi|carry != 0 {
+			return 0, i
+		}
+		n = lo
+	}
+	return n, -1
+}
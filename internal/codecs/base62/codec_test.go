@@ -0,0 +1,102 @@
+package base62
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+func TestAlphabet(t *testing.T) {
+	result := Alphabet()
+	if string(result[:]) != "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" {
+		t.Errorf("Alphabet() = %v, want %v", string(result[:]), "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i-1] >= result[i] {
+			t.Errorf("Alphabet() is not in ascending order at position %d", i)
+		}
+	}
+}
+
+func TestAlphabetLookup(t *testing.T) {
+	if *Table != *lookup.FromMap(AlphabetLookup()) {
+		t.Errorf("Table does not match the alphabet lookup")
+	}
+	for _, c := range []byte("+/_-") {
+		if Table[c] != lookup.Invalid {
+			t.Errorf("Table[%q] = %v, want %v", c, Table[c], lookup.Invalid)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    uint64
+		expected string
+		shortest string
+	}{
+		{name: "Input: 0x0", input: 0x0, expected: "00000000000", shortest: "0"},
+		{name: "Input: 0x1", input: 0x1, expected: "00000000001", shortest: "1"},
+		{name: "Input: 0x123456789ABCDEF0", input: 0x123456789ABCDEF0, expected: "1YtudU73D5k", shortest: "1YtudU73D5k"},
+		{name: "Input: 0xFFFFFFFFFFFFFFFF", input: 0xFFFFFFFFFFFFFFFF, expected: "LygHa16AHYF", shortest: "LygHa16AHYF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s [11]byte
+			Encode(&s, tt.input, &Digits)
+			if string(s[:]) != tt.expected {
+				t.Errorf("Encode() = %v, want %v", string(s[:]), tt.expected)
+			}
+			if shortest := string(s[Shortest(&s, &Digits):]); shortest != tt.shortest {
+				t.Errorf("Shortest() = %v, want %v", shortest, tt.shortest)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected uint64
+		overflow int
+	}{
+		{name: "Input: 00000000000", input: "00000000000", expected: 0x0, overflow: -1},
+		{name: "Input: 0", input: "0", expected: 0x0, overflow: -1},
+		{name: "Input: 1", input: "1", expected: 0x1, overflow: -1},
+		{name: "Input: 1YtudU73D5k", input: "1YtudU73D5k", expected: 0x123456789ABCDEF0, overflow: -1},
+		{name: "Input: LygHa16AHYF", input: "LygHa16AHYF", expected: 0xFFFFFFFFFFFFFFFF, overflow: -1},
+		{name: "Input: LygHa16AHYG", input: "LygHa16AHYG", expected: 0x0, overflow: 10},
+		{name: "Input: LygHa16AHYF1", input: "LygHa16AHYF1", expected: 0x0, overflow: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, overflow := Decode([]byte(tt.input), Table)
+			if result != tt.expected {
+				t.Errorf("Decode() = %x, want %x", result, tt.expected)
+			}
+			if overflow != tt.overflow {
+				t.Errorf("Decode() overflow = %v, want %v", overflow, tt.overflow)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		n := r.Uint64() >> (i % 64)
+		var s [11]byte
+		Encode(&s, n, &Digits)
+		if result, overflow := Decode(s[:], Table); result != n || overflow != -1 {
+			t.Errorf("Decode(Encode(%x)) = %x, %v", n, result, overflow)
+		}
+		if result, overflow := Decode(s[Shortest(&s, &Digits):], Table); result != n || overflow != -1 {
+			t.Errorf("Decode(Shortest(Encode(%x))) = %x, %v", n, result, overflow)
+		}
+	}
+}
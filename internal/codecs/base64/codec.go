@@ -1,5 +1,7 @@
 package base64
 
+import "github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+
 func Alphabet() [64]byte {
 	return [64]byte{
 		'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J',
@@ -69,10 +71,18 @@ func MimeAlphabetLookup() map[byte]uint64 {
 	}
 }
 
-// Encode encodes a number into a base64 string
-func Encode(s *[11]byte, n uint64, d func() [64]byte) {
-	var digits = d()
+// The digits and reverse lookup tables of the alphabets are precomputed once, they must not be modified
+var (
+	StdDigits  = Alphabet()
+	UrlDigits  = UrlAlphabet()
+	MimeDigits = MimeAlphabet()
+	StdTable   = lookup.New(StdDigits[:])
+	UrlTable   = lookup.New(UrlDigits[:])
+	MimeTable  = lookup.New(MimeDigits[:])
+)
 
+// Encode encodes a number into a base64 string
+func Encode(s *[11]byte, n uint64, digits *[64]byte) {
 	var ln uint64
 
 	for i := 0; i < 8; i++ {
@@ -85,16 +95,14 @@ func Encode(s *[11]byte, n uint64, d func() [64]byte) {
 
 }
 
-// Decode decodes a base64 string into a number
-func Decode(s *[11]byte, d func() map[byte]uint64) uint64 {
-	var digitsLookup = d()
-
+// Decode decodes a base64 string into a number, characters that are not in the lookup table decode as zero
+func Decode(s *[11]byte, t *lookup.Table) uint64 {
 	var ln uint64
 
 	for i := 0; i < 10; i++ {
-		ln = (ln << 6) | digitsLookup[s[i]]
+		ln = (ln << 6) | t.Value(s[i])
 	}
-	ln = (ln << 4) | digitsLookup[s[10]]>>2
+	ln = (ln << 4) | t.Value(s[10])>>2
 
 	n := uint64(0)
 
@@ -3,6 +3,8 @@ package base64
 import (
 	"reflect"
 	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
 )
 
 func TestAlphabet(t *testing.T) {
@@ -204,50 +206,50 @@ func TestEncode(t *testing.T) {
 		name     string
 		input    uint64
 		expected [11]byte
-		alphabet func() [64]byte
+		digits   *[64]byte
 	}{
 		{
 			name:     "Input: 0x123456789ABCDEF0",
 			input:    0x123456789ABCDEF0,
 			expected: [11]byte{'8', 'N', '6', '8', 'm', 'n', 'h', 'W', 'N', 'B', 'I'},
-			alphabet: Alphabet,
+			digits:   &StdDigits,
 		},
 		{
 			name:     "Input: 0x0",
 			input:    0x0,
 			expected: [11]byte{'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A'},
-			alphabet: Alphabet,
+			digits:   &StdDigits,
 		},
 		{
 			name:     "Input: 0xFFFFFFFFFFFFFFFF",
 			input:    0xFFFFFFFFFFFFFFFF,
 			expected: [11]byte{'/', '/', '/', '/', '/', '/', '/', '/', '/', '/', '8'},
-			alphabet: Alphabet,
+			digits:   &StdDigits,
 		},
 		{
 			name:     "Input: 0xFFFBDFFFAFFFFFFF normal",
 			input:    0xFFFBDFFFAFFFFFFF,
 			expected: [11]byte{'/', '/', '/', '/', 'r', '/', '/', 'f', '+', '/', '8'},
-			alphabet: Alphabet,
+			digits:   &StdDigits,
 		},
 		{
 			name:     "Input: 0xFFFBDFFFAFFFFFFF url",
 			input:    0xFFFBDFFFAFFFFFFF,
 			expected: [11]byte{'_', '_', '_', '_', 'r', '_', '_', 'f', '-', '_', '8'},
-			alphabet: UrlAlphabet,
+			digits:   &UrlDigits,
 		},
 		{
 			name:     "Input: 0xFFFBDFFFAFFFFFFF mime",
 			input:    0xFFFBDFFFAFFFFFFF,
 			expected: [11]byte{',', ',', ',', ',', 'r', ',', ',', 'f', '+', ',', '8'},
-			alphabet: MimeAlphabet,
+			digits:   &MimeDigits,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var s [11]byte
-			Encode(&s, tt.input, tt.alphabet)
+			Encode(&s, tt.input, tt.digits)
 			if !reflect.DeepEqual(s, tt.expected) {
 				t.Errorf("Encode() = %v, want %v", s, tt.expected)
 			}
@@ -260,52 +262,78 @@ func TestDecode(t *testing.T) {
 		name     string
 		input    [11]byte
 		expected uint64
-		alphabet func() map[byte]uint64
+		table    *lookup.Table
 	}{
 		{
 			name:     "Input: 8N68mnhWNBI",
 			input:    [11]byte{'8', 'N', '6', '8', 'm', 'n', 'h', 'W', 'N', 'B', 'I'},
 			expected: 0x123456789ABCDEF0,
-			alphabet: AlphabetLookup,
+			table:    StdTable,
 		},
 		{
 			name:     "Input: AAAAAAAAAAA",
 			input:    [11]byte{'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A'},
 			expected: 0x0,
-			alphabet: AlphabetLookup,
+			table:    StdTable,
 		},
 		{
 			name:     "Input: ///////////8",
 			input:    [11]byte{'/', '/', '/', '/', '/', '/', '/', '/', '/', '/', '8'},
 			expected: 0xFFFFFFFFFFFFFFFF,
-			alphabet: AlphabetLookup,
+			table:    StdTable,
 		},
 		{
 			name:     "Input: ////r//f+/8",
 			input:    [11]byte{'/', '/', '/', '/', 'r', '/', '/', 'f', '+', '/', '8'},
 			expected: 0xFFFBDFFFAFFFFFFF,
-			alphabet: AlphabetLookup,
+			table:    StdTable,
 		},
 		{
 			name:     "Input: ____r__f-_8",
 			input:    [11]byte{'_', '_', '_', '_', 'r', '_', '_', 'f', '-', '_', '8'},
 			expected: 0xFFFBDFFFAFFFFFFF,
-			alphabet: UrlAlphabetLookup,
+			table:    UrlTable,
 		},
 		{
 			name:     "Input: ,,,,,r,,f+,8",
 			input:    [11]byte{',', ',', ',', ',', 'r', ',', ',', 'f', '+', ',', '8'},
 			expected: 0xFFFBDFFFAFFFFFFF,
-			alphabet: MimeAlphabetLookup,
+			table:    MimeTable,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := Decode(&tt.input, tt.alphabet)
+			result := Decode(&tt.input, tt.table)
 			if result != tt.expected {
 				t.Errorf("Decode() = %v, want %v", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestTables(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  *lookup.Table
+		lookup map[byte]uint64
+	}{
+		{name: "StdTable", table: StdTable, lookup: AlphabetLookup()},
+		{name: "UrlTable", table: UrlTable, lookup: UrlAlphabetLookup()},
+		{name: "MimeTable", table: MimeTable, lookup: MimeAlphabetLookup()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if *tt.table != *lookup.FromMap(tt.lookup) {
+				t.Errorf("%v does not match its alphabet lookup", tt.name)
+			}
+		})
+	}
+}
+
+func TestDecode_InvalidCharacter(t *testing.T) {
+	input := [11]byte{'#', 'Q', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A'}
+	if result := Decode(&input, StdTable); result != 1 {
+		t.Errorf("Decode() = %x, want %x", result, 1)
+	}
+}
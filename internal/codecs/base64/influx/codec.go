@@ -1,5 +1,7 @@
 package influx
 
+import "github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+
 func Alphabet() [64]byte {
 	return [64]byte{
 		'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
@@ -23,9 +25,14 @@ func AlphabetLookup() map[byte]uint64 {
 	}
 }
 
+// The digits and reverse lookup table of the alphabet are precomputed once, they must not be modified
+var (
+	Digits = Alphabet()
+	Table  = lookup.New(Digits[:])
+)
+
 // Encode encodes a number into an Influx style base64 string
-func Encode(s *[11]byte, n uint64, d func() [64]byte) {
-	digits := d()
+func Encode(s *[11]byte, n uint64, digits *[64]byte) {
 	s[10], n = digits[n&0x3f], n>>6
 	s[9], n = digits[n&0x3f], n>>6
 	s[8], n = digits[n&0x3f], n>>6
@@ -39,14 +46,13 @@ func Encode(s *[11]byte, n uint64, d func() [64]byte) {
 	s[0] = digits[n&0x3f]
 }
 
-func Decode(s *[11]byte, d func() map[byte]uint64) uint64 {
-	digitsLookup := d()
-
+// Decode decodes an Influx style base64 string into a number, characters that are not in the lookup table decode as zero
+func Decode(s *[11]byte, t *lookup.Table) uint64 {
 	// Decode the input
 	var n uint64
-	n = (n << 4) | digitsLookup[s[0]]
+	n = (n << 4) | t.Value(s[0])
 	for i := 1; i < 11; i++ {
-		n = (n << 6) | digitsLookup[s[i]]
+		n = (n << 6) | t.Value(s[i])
 	}
 
 	return n
@@ -3,6 +3,8 @@ package influx
 import (
 	"reflect"
 	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
 )
 
 func TestAlphabet(t *testing.T) {
@@ -68,38 +70,38 @@ func TestEncode(t *testing.T) {
 		name     string
 		input    uint64
 		expected [11]byte
-		alphabet func() [64]byte
+		digits   *[64]byte
 	}{
 		{
 			name:     "Input: 0x123456789ABCDEF0",
 			input:    0x123456789ABCDEF0,
 			expected: [11]byte{'1', '8', 'p', 'L', 'c', 'Y', 'Q', 'k', 'D', 'w', 'l'},
-			alphabet: Alphabet,
+			digits:   &Digits,
 		},
 		{
 			name:     "Input: 0x0",
 			input:    0x0,
 			expected: [11]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'},
-			alphabet: Alphabet,
+			digits:   &Digits,
 		},
 		{
 			name:     "Input: 0xFFFFFFFFFFFFFFFF",
 			input:    0xFFFFFFFFFFFFFFFF,
 			expected: [11]byte{'F', '~', '~', '~', '~', '~', '~', '~', '~', '~', '~'},
-			alphabet: Alphabet,
+			digits:   &Digits,
 		},
 		{
 			name:     "Input: 0xFFFBDFFFAFFFFFFF",
 			input:    0xFFFBDFFFAFFFFFFF,
 			expected: [11]byte{'F', '~', 'w', 's', '~', 'z', 'k', '~', '~', '~', '~'},
-			alphabet: Alphabet,
+			digits:   &Digits,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var s [11]byte
-			Encode(&s, tt.input, tt.alphabet)
+			Encode(&s, tt.input, tt.digits)
 			if !reflect.DeepEqual(s, tt.expected) {
 				t.Errorf("Encode() = %v, want %v", s, tt.expected)
 			}
@@ -137,10 +139,23 @@ func TestDecode(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := Decode(&tt.input, AlphabetLookup)
+			result := Decode(&tt.input, Table)
 			if result != tt.expected {
 				t.Errorf("Decode() = %v, want %v", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestTable(t *testing.T) {
+	if *Table != *lookup.FromMap(AlphabetLookup()) {
+		t.Errorf("Table does not match the alphabet lookup")
+	}
+}
+
+func TestDecode_InvalidCharacter(t *testing.T) {
+	input := [11]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '1', '#'}
+	if result := Decode(&input, Table); result != 0x40 {
+		t.Errorf("Decode() = %x, want %x", result, 0x40)
+	}
+}
@@ -0,0 +1,49 @@
+package crockford
+
+import "github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+
+func Alphabet() [32]byte {
+	return [32]byte{
+		'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+		'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'J', 'K',
+		'M', 'N', 'P', 'Q', 'R', 'S', 'T', 'V', 'W', 'X',
+		'Y', 'Z'}
+}
+
+// AlphabetLookup returns the case-insensitive reverse lookup of the alphabet.
+// The ambiguous characters I and L decode as 1 and O decodes as 0, as required by the Crockford specification.
+func AlphabetLookup() map[byte]uint64 {
+	return map[byte]uint64{
+		'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+		'A': 10, 'B': 11, 'C': 12, 'D': 13, 'E': 14, 'F': 15, 'G': 16, 'H': 17, 'J': 18, 'K': 19,
+		'M': 20, 'N': 21, 'P': 22, 'Q': 23, 'R': 24, 'S': 25, 'T': 26, 'V': 27, 'W': 28, 'X': 29,
+		'Y': 30, 'Z': 31,
+		'a': 10, 'b': 11, 'c': 12, 'd': 13, 'e': 14, 'f': 15, 'g': 16, 'h': 17, 'j': 18, 'k': 19,
+		'm': 20, 'n': 21, 'p': 22, 'q': 23, 'r': 24, 's': 25, 't': 26, 'v': 27, 'w': 28, 'x': 29,
+		'y': 30, 'z': 31,
+		'I': 1, 'i': 1, 'L': 1, 'l': 1, 'O': 0, 'o': 0,
+	}
+}
+
+// The digits and reverse lookup table of the alphabet are precomputed once, they must not be modified
+var (
+	Digits = Alphabet()
+	Table  = lookup.FromMap(AlphabetLookup())
+)
+
+// Encode encodes a number into a Crockford base32 string, the first character carries the 4 most significant bits
+func Encode(s *[13]byte, n uint64, digits *[32]byte) {
+	for i := 12; i > 0; i-- {
+		s[i], n = digits[n&0x1f], n>>5
+	}
+	s[0] = digits[n&0x1f]
+}
+
+// Decode decodes a Crockford base32 string into a number, characters that are not in the lookup table decode as zero
+func Decode(s *[13]byte, t *lookup.Table) uint64 {
+	var n uint64
+	for i := 0; i < 13; i++ {
+		n = (n << 5) | t.Value(s[i])
+	}
+	return n
+}
@@ -0,0 +1,99 @@
+package crockford
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+func TestAlphabet(t *testing.T) {
+	result := Alphabet()
+	if string(result[:]) != "0123456789ABCDEFGHJKMNPQRSTVWXYZ" {
+		t.Errorf("Alphabet() = %v, want %v", string(result[:]), "0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	}
+}
+
+func TestAlphabetLookup(t *testing.T) {
+	digits := Alphabet()
+	m := AlphabetLookup()
+	for i, c := range digits {
+		if m[c] != uint64(i) {
+			t.Errorf("AlphabetLookup()[%q] = %v, want %v", c, m[c], i)
+		}
+		if c >= 'A' && m[c+'a'-'A'] != uint64(i) {
+			t.Errorf("AlphabetLookup()[%q] = %v, want %v", c+'a'-'A', m[c+'a'-'A'], i)
+		}
+	}
+	for c, want := range map[byte]uint64{'I': 1, 'i': 1, 'L': 1, 'l': 1, 'O': 0, 'o': 0} {
+		if m[c] != want {
+			t.Errorf("AlphabetLookup()[%q] = %v, want %v", c, m[c], want)
+		}
+	}
+	for _, c := range []byte("Uu-") {
+		if _, ok := m[c]; ok {
+			t.Errorf("AlphabetLookup() contains %q", c)
+		}
+	}
+	if *Table != *lookup.FromMap(m) {
+		t.Errorf("Table does not match the alphabet lookup")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    uint64
+		expected string
+	}{
+		{name: "Input: 0x0", input: 0x0, expected: "0000000000000"},
+		{name: "Input: 0x1", input: 0x1, expected: "0000000000001"},
+		{name: "Input: 0x123456789ABCDEF0", input: 0x123456789ABCDEF0, expected: "14D2PF2DBSQQG"},
+		{name: "Input: 0xFFFFFFFFFFFFFFFF", input: 0xFFFFFFFFFFFFFFFF, expected: "FZZZZZZZZZZZZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s [13]byte
+			Encode(&s, tt.input, &Digits)
+			if string(s[:]) != tt.expected {
+				t.Errorf("Encode() = %v, want %v", string(s[:]), tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected uint64
+	}{
+		{name: "Input: 0000000000000", input: "0000000000000", expected: 0x0},
+		{name: "Input: 14D2PF2DBSQQG", input: "14D2PF2DBSQQG", expected: 0x123456789ABCDEF0},
+		{name: "Input: 14d2pf2dbsqqg", input: "14d2pf2dbsqqg", expected: 0x123456789ABCDEF0},
+		{name: "Input: OOOOOOOOOOOOI", input: "OOOOOOOOOOOOI", expected: 0x1},
+		{name: "Input: ooooooooooooL", input: "ooooooooooooL", expected: 0x1},
+		{name: "Input: FZZZZZZZZZZZZ", input: "FZZZZZZZZZZZZ", expected: 0xFFFFFFFFFFFFFFFF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s [13]byte
+			copy(s[:], tt.input)
+			if result := Decode(&s, Table); result != tt.expected {
+				t.Errorf("Decode() = %x, want %x", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, n := range []uint64{0, 1, 31, 32, 1 << 60, 1<<64 - 1} {
+		var s [13]byte
+		Encode(&s, n, &Digits)
+		if result := Decode(&s, Table); !reflect.DeepEqual(result, n) {
+			t.Errorf("Decode(Encode(%x)) = %x", n, result)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package decimal
+
+import (
+	"math/bits"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+// Alphabet returns the decimal digits
+func Alphabet() [10]byte {
+	return [10]byte{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
+}
+
+// The digits and reverse lookup table of the alphabet are precomputed once, they must not be modified
+var (
+	Digits = Alphabet()
+	Table  = lookup.New(Digits[:])
+)
+
+// Encode encodes a number into a decimal string of 20 characters, padded with zeros
+func Encode(s *[20]byte, n uint64) {
+	for i := 19; i >= 0; i-- {
+		s[i], n = Digits[n%10], n/10
+	}
+}
+
+// Shortest returns the position of the first significant digit of an encoded string, the shortest form of the number
+// starts at this position. The shortest form of zero is a single zero.
+func Shortest(s *[20]byte) int {
+	i := 0
+	for i < 19 && s[i] == '0' {
+		i++
+	}
+	return i
+}
+
+// Decode decodes a decimal string of any length into a number, characters that are not in the lookup table decode as
+// zero. Returns the position of the character that overflows 64 bits, or -1 if the number fits.
+func Decode(s []byte, t *lookup.Table) (uint64, int) {
+	var n uint64
+	for i, c := range s {
+		hi, lo := bits.Mul64(n, 10)
+		lo, carry := bits.Add64(lo, t.Value(c), 0)
+		if hi|carry != 0 {
+			return 0, i
+		}
+		n = lo
+	}
+	return n, -1
+}
@@ -0,0 +1,76 @@
+package decimal
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    uint64
+		expected string
+		shortest string
+	}{
+		{name: "Input: 0x0", input: 0x0, expected: "00000000000000000000", shortest: "0"},
+		{name: "Input: 0x1", input: 0x1, expected: "00000000000000000001", shortest: "1"},
+		{name: "Input: 0xA000B00F0A023452", input: 0xA000B00F0A023452, expected: "11529408624707384402", shortest: "11529408624707384402"},
+		{name: "Input: 0xFFFFFFFFFFFFFFFF", input: 0xFFFFFFFFFFFFFFFF, expected: "18446744073709551615", shortest: "18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s [20]byte
+			Encode(&s, tt.input)
+			if string(s[:]) != tt.expected {
+				t.Errorf("Encode() = %v, want %v", string(s[:]), tt.expected)
+			}
+			if shortest := string(s[Shortest(&s):]); shortest != tt.shortest {
+				t.Errorf("Shortest() = %v, want %v", shortest, tt.shortest)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected uint64
+		overflow int
+	}{
+		{name: "Input: 0", input: "0", expected: 0x0, overflow: -1},
+		{name: "Input: 00000000000000000001", input: "00000000000000000001", expected: 0x1, overflow: -1},
+		{name: "Input: 18446744073709551615", input: "18446744073709551615", expected: 0xFFFFFFFFFFFFFFFF, overflow: -1},
+		{name: "Input: 18446744073709551616", input: "18446744073709551616", expected: 0x0, overflow: 19},
+		{name: "Input: 100000000000000000000", input: "100000000000000000000", expected: 0x0, overflow: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, overflow := Decode([]byte(tt.input), Table)
+			if result != tt.expected {
+				t.Errorf("Decode() = %v, want %v", result, tt.expected)
+			}
+			if overflow != tt.overflow {
+				t.Errorf("Decode() overflow = %v, want %v", overflow, tt.overflow)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		n := r.Uint64() >> (i % 64)
+		var s [20]byte
+		Encode(&s, n)
+		if shortest := string(s[Shortest(&s):]); shortest != strconv.FormatUint(n, 10) {
+			t.Errorf("Shortest(Encode(%v)) = %v", n, shortest)
+		}
+		if result, overflow := Decode(s[:], Table); result != n || overflow != -1 {
+			t.Errorf("Decode(Encode(%v)) = %v, %v", n, result, overflow)
+		}
+	}
+}
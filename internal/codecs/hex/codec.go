@@ -1,5 +1,7 @@
 package hex
 
+import "github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+
 type Digits [16]byte
 
 func Upper() Digits {
@@ -30,20 +32,27 @@ func LowerLookup() map[byte]uint64 {
 	}
 }
 
-func Encode(s *[16]byte, n uint64, d func() Digits) {
-	var digits = d()
+// The digits and reverse lookup tables are precomputed once, they must not be modified
+var (
+	LowerDigits = Lower()
+	UpperDigits = Upper()
+	LowerTable  = lookup.New(LowerDigits[:])
+	UpperTable  = lookup.New(UpperDigits[:])
+)
+
+// Encode encodes a number into a hex string
+func Encode(s *[16]byte, n uint64, digits *Digits) {
 	for i := 0; i < 16; i++ {
 		s[i], n = digits[n>>60&0xf], n<<4
 	}
 }
 
-func Decode(s *[16]byte, d func() map[byte]uint64) uint64 {
-	digitsLookup := d()
-
+// Decode decodes a hex string into a number, characters that are not in the lookup table decode as zero
+func Decode(s *[16]byte, t *lookup.Table) uint64 {
 	// Decode the input
 	var n uint64
 	for i := 0; i < 16; i++ {
-		n = (n << 4) | uint64(digitsLookup[s[i]])
+		n = (n << 4) | t.Value(s[i])
 	}
 
 	return n
@@ -3,6 +3,8 @@ package hex
 import (
 	"reflect"
 	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
 )
 
 func digitsToString(digits Digits) string {
@@ -27,31 +29,31 @@ func TestEncode(t *testing.T) {
 		name     string
 		input    uint64
 		expected [16]byte
-		digits   func() Digits
+		digits   *Digits
 	}{
 		{
 			name:     "Input: 0x123456789abcdef0",
 			input:    0x123456789ABCDEF0,
 			expected: [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f', '0'},
-			digits:   Lower,
+			digits:   &LowerDigits,
 		},
 		{
 			name:     "Input: 0x123456789ABCDEF0",
 			input:    0x123456789ABCDEF0,
 			expected: [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', '0'},
-			digits:   Upper,
+			digits:   &UpperDigits,
 		},
 		{
 			name:     "Input: 0x0",
 			input:    0x0,
 			expected: [16]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'},
-			digits:   Upper,
+			digits:   &UpperDigits,
 		},
 		{
 			name:     "Input: 0xFFFFFFFFFFFFFFFF",
 			input:    0xFFFFFFFFFFFFFFFF,
 			expected: [16]byte{'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F'},
-			digits:   Upper,
+			digits:   &UpperDigits,
 		},
 	}
 
@@ -68,34 +70,34 @@ func TestEncode(t *testing.T) {
 
 func TestDecode(t *testing.T) {
 	tests := []struct {
-		name         string
-		input        [16]byte
-		expected     uint64
-		digitsLookup func() map[byte]uint64
+		name     string
+		input    [16]byte
+		expected uint64
+		table    *lookup.Table
 	}{
 		{
-			name:         "Input: 0x123456789ABCDEF0",
-			input:        [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', '0'},
-			expected:     0x123456789ABCDEF0,
-			digitsLookup: UpperLookup,
+			name:     "Input: 0x123456789ABCDEF0",
+			input:    [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', '0'},
+			expected: 0x123456789ABCDEF0,
+			table:    UpperTable,
 		},
 		{
-			name:         "Input: 0x123456789abcdef0",
-			input:        [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f', '0'},
-			expected:     0x123456789ABCDEF0,
-			digitsLookup: LowerLookup,
+			name:     "Input: 0x123456789abcdef0",
+			input:    [16]byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f', '0'},
+			expected: 0x123456789ABCDEF0,
+			table:    LowerTable,
 		},
 		{
-			name:         "Input: 0x0",
-			input:        [16]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'},
-			expected:     0x0,
-			digitsLookup: UpperLookup,
+			name:     "Input: 0x0",
+			input:    [16]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0'},
+			expected: 0x0,
+			table:    UpperTable,
 		},
 		{
-			name:         "Input: 0xFFFFFFFFFFFFFFFF",
-			input:        [16]byte{'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F'},
-			expected:     0xFFFFFFFFFFFFFFFF,
-			digitsLookup: UpperLookup,
+			name:     "Input: 0xFFFFFFFFFFFFFFFF",
+			input:    [16]byte{'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F', 'F'},
+			expected: 0xFFFFFFFFFFFFFFFF,
+			table:    UpperTable,
 		},
 	}
 
@@ -103,9 +105,25 @@ func TestDecode(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var s [16]byte
 			copy(s[:], tt.input[:])
-			if got := Decode(&s, tt.digitsLookup); got != tt.expected {
+			if got := Decode(&s, tt.table); got != tt.expected {
 				t.Errorf("Decode() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
 }
+
+func TestTables(t *testing.T) {
+	if *LowerTable != *lookup.FromMap(LowerLookup()) {
+		t.Errorf("LowerTable does not match the lower lookup")
+	}
+	if *UpperTable != *lookup.FromMap(UpperLookup()) {
+		t.Errorf("UpperTable does not match the upper lookup")
+	}
+}
+
+func TestDecode_InvalidCharacter(t *testing.T) {
+	s := [16]byte{'0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '0', '1', 'g'}
+	if got := Decode(&s, LowerTable); got != 0x10 {
+		t.Errorf("Decode() = %x, want %x", got, 0x10)
+	}
+}
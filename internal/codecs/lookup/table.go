@@ -0,0 +1,49 @@
+package lookup
+
+// Invalid is the value of a byte in a Table that is not part of the alphabet
+const Invalid = 0xFF
+
+// Table maps every byte to its digit value in an alphabet, or to Invalid.
+// Tables are precomputed once per alphabet and must not be modified after creation.
+type Table [256]byte
+
+// New returns the reverse lookup table of the digits of an alphabet
+func New(digits []byte) *Table {
+	var t Table
+	for i := range t {
+		t[i] = Invalid
+	}
+	for i, c := range digits {
+		t[c] = byte(i)
+	}
+	return &t
+}
+
+// FromMap returns a lookup table built from a lookup map
+func FromMap(m map[byte]uint64) *Table {
+	var t Table
+	for i := range t {
+		t[i] = Invalid
+	}
+	for c, v := range m {
+		t[c] = byte(v)
+	}
+	return &t
+}
+
+// Value returns the digit value of c, bytes that are not part of the alphabet have the value 0
+func (t *Table) Value(c byte) uint64 {
+	v := t[c]
+	// (v >> 7) - 1 is 0x00 for Invalid and 0xFF for every digit value
+	return uint64(v & ((v >> 7) - 1))
+}
+
+// Check returns the position of the first byte of s that is not part of the alphabet, or -1 if all bytes are valid
+func (t *Table) Check(s []byte) int {
+	for i, c := range s {
+		if t[c] == Invalid {
+			return i
+		}
+	}
+	return -1
+}
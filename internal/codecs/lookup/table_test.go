@@ -0,0 +1,71 @@
+package lookup
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	table := New([]byte("0123456789abcdef"))
+	for i, c := range []byte("0123456789abcdef") {
+		if table[c] != byte(i) {
+			t.Errorf("table[%q] = %v, want %v", c, table[c], i)
+		}
+	}
+	for _, c := range []byte("gG/\x00\xff") {
+		if table[c] != Invalid {
+			t.Errorf("table[%q] = %v, want %v", c, table[c], Invalid)
+		}
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	m := map[byte]uint64{'a': 0, 'b': 1, 'c': 63}
+	table := FromMap(m)
+	for c, v := range m {
+		if uint64(table[c]) != v {
+			t.Errorf("table[%q] = %v, want %v", c, table[c], v)
+		}
+	}
+	if table['d'] != Invalid {
+		t.Errorf("table['d'] = %v, want %v", table['d'], Invalid)
+	}
+}
+
+func TestTable_Value(t *testing.T) {
+	table := New([]byte("0123456789abcdef"))
+	tests := []struct {
+		name  string
+		input byte
+		want  uint64
+	}{
+		{name: "first digit", input: '0', want: 0},
+		{name: "last digit", input: 'f', want: 15},
+		{name: "invalid", input: 'g', want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Value(tt.input); got != tt.want {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Check(t *testing.T) {
+	table := New([]byte("0123456789abcdef"))
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "valid", input: "0123abc", want: -1},
+		{name: "empty", input: "", want: -1},
+		{name: "invalid first", input: "x123", want: 0},
+		{name: "invalid last", input: "123X", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Check([]byte(tt.input)); got != tt.want {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
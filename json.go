@@ -0,0 +1,94 @@
+package snowflake
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// MarshalJSON implements json.Marshaler, the ID is marshalled as a quoted decimal string.
+// A quoted string prevents loss of precision in JavaScript clients, which cannot represent integers above 2^53.
+// Decimal is the only string form of the JSON of an ID, as the other encodings share characters and lengths with it.
+// Use a wrapper type such as Influx64ID or Base64ID, or a Codec, for the other encodings.
+func (id ID) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 22)
+	b = append(b, '"')
+	b = id.AppendDecimal(b)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a quoted decimal string, as marshalled by MarshalJSON or zero padded to 20 digits, and a bare number for
+// backward compatibility. A JSON null leaves the ID unchanged.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v, err := parseDecimalForm([]byte(s))
+		if err != nil {
+			return err
+		}
+		*id = v
+		return nil
+	}
+	v, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
+
+// parseDecimalForm parses the decimal string of DecimalString or PaddedDecimalString. Other leading zeros are
+// rejected, so the Influx64 form of an ID below 2^60, which starts with 0, is not mistaken for a decimal.
+func parseDecimalForm(s []byte) (ID, error) {
+	if len(s) > 1 && len(s) < 20 && s[0] == '0' {
+		return 0, &LengthError{Length: len(s), Expected: 20}
+	}
+	return ParseDecimalBytes(s)
+}
+
+// decodedIDJSON is the JSON representation of a DecodedID
+type decodedIDJSON struct {
+	ID        ID                `json:"id"`
+	Timestamp string            `json:"timestamp"`
+	MachineID uint64            `json:"machine_id"`
+	Sequence  uint64            `json:"sequence"`
+	Node      map[string]uint64 `json:"node,omitempty"`
+	Fields    map[string]uint64 `json:"fields,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, the timestamp is marshalled as an RFC3339 string including fractional seconds.
+// The node and extra fields are marshalled as objects of their names and values.
+func (id DecodedID) MarshalJSON() ([]byte, error) {
+	v := decodedIDJSON{
+		ID:        ID(id.ID),
+		Timestamp: id.Time.UTC().Format(time.RFC3339Nano),
+		MachineID: id.MachineID,
+		Sequence:  id.Sequence,
+	}
+	v.Node = fieldMap(id.Node[:])
+	v.Fields = fieldMap(id.Extra[:])
+	return json.Marshal(v)
+}
+
+// fieldMap returns the named field values as a map, or nil if no field is named
+func fieldMap(values []FieldValue) map[string]uint64 {
+	var m map[string]uint64
+	for _, field := range values {
+		if field.Name == "" {
+			continue
+		}
+		if m == nil {
+			m = map[string]uint64{}
+		}
+		m[field.Name] = field.Value
+	}
+	return m
+}
@@ -0,0 +1,137 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestID_MarshalJSON tests that the ID is marshalled as a quoted string
+func TestID_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		ID ID `json:"id"`
+	}{ID: 0xA000B00F0A023452})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(b) != `{"id":"11529408624707384402"}` {
+		t.Errorf("got %s", b)
+	}
+}
+
+// TestID_UnmarshalJSON tests that the JSON unmarshalling of the ID only accepts decimals and rejects other encodings
+func TestID_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  ID
+		err   error
+	}{
+		{name: "Decimal", input: `"11529408624707384402"`, want: 0xA000B00F0A023452},
+		{name: "Decimal of 11 digits", input: `"12345678901"`, want: 12345678901},
+		{name: "Zero", input: `"0"`, want: 0},
+		{name: "Zero padded decimal", input: `"00000000000000000064"`, want: 64},
+		{name: "Twitter id_str", input: `"1541815603606036480"`, want: 1541815603606036480},
+		{name: "Largest decimal", input: `"18446744073709551615"`, want: 1<<64 - 1},
+		{name: "Decimal overflow", input: `"18446744073709551616"`, want: 42, err: ErrOverflow},
+		{name: "Decimal too long", input: `"123456789012345678901"`, want: 42, err: ErrInvalidLength},
+		{name: "Number", input: `11529408624707384402`, want: 0xA000B00F0A023452},
+		{name: "Null", input: `null`, want: 42},
+		{name: "Empty", input: `""`, want: 42, err: ErrInvalidLength},
+		// The Influx64 form of an ID below 2^60 starts with 0, a decimal only has leading zeros when it is padded
+		{name: "Influx64 of digits", input: `"00000000010"`, want: 42, err: ErrInvalidLength},
+		{name: "Influx64", input: `"A00h0xA0ZHI"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Base64 of 1", input: `"AQAAAAAAAAA"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Base64", input: `"UjQCCg+wAKA"`, want: 42, err: ErrInvalidCharacter},
+		{name: "URL base64", input: `"UjQCCg-wAKA"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Crockford", input: `"A005G1W504D2J"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Hex", input: `"a000b00f0a023452"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Negative", input: `"-1"`, want: 42, err: ErrInvalidCharacter},
+		{name: "Invalid character", input: `"A00h0xA0Z#I"`, want: 42, err: ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := ID(42)
+			err := json.Unmarshal([]byte(tt.input), &id)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if id != tt.want {
+				t.Errorf("got %v, want %v", uint64(id), uint64(tt.want))
+			}
+		})
+	}
+}
+
+// TestID_MarshalJSON_RoundTrip tests that marshalled IDs unmarshal to the same ID, including the IDs whose Influx64
+// form only has digits
+func TestID_MarshalJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		id   ID
+		want string
+	}{
+		{id: 0, want: `"0"`},
+		{id: 64, want: `"64"`},
+		{id: IDFromInflux64String("12345678901"), want: `"1189812668901396481"`},
+		{id: 0xA000B00F0A023452, want: `"11529408624707384402"`},
+		{id: 1<<64 - 1, want: `"18446744073709551615"`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.id)
+		if err != nil || string(b) != tt.want {
+			t.Errorf("Marshal(%v) = %s, %v, want %v", uint64(tt.id), b, err, tt.want)
+		}
+		var id ID
+		if err = json.Unmarshal(b, &id); err != nil || id != tt.id {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", b, uint64(id), err, uint64(tt.id))
+		}
+	}
+}
+
+// TestID_UnmarshalJSON_InvalidNumber tests that invalid numbers are rejected
+func TestID_UnmarshalJSON_InvalidNumber(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalJSON([]byte(`-1`)); err == nil {
+		t.Errorf("expected an error, got nil")
+	}
+	if err := id.UnmarshalJSON([]byte(`18446744073709551616`)); err == nil {
+		t.Errorf("expected an error, got nil")
+	}
+}
+
+// TestDecodedID_MarshalJSON tests the JSON form of the DecodedID
+func TestDecodedID_MarshalJSON(t *testing.T) {
+	d := DecodedID{
+		ID:        4202496,
+		Timestamp: 1,
+		MachineID: 2,
+		Sequence:  3,
+		Time:      time.UnixMilli(1709247600001),
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := `{"id":"4202496","timestamp":"2024-02-29T23:00:00.001Z","machine_id":2,"sequence":3}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
+// ExampleID_MarshalJSON is an example of the JSON marshalling of the ID
+func ExampleID_MarshalJSON() {
+	b, _ := json.Marshal(map[string]ID{"id": 11529408624707384402})
+	fmt.Println(string(b))
+
+	var v struct {
+		Decimal ID `json:"decimal"`
+		Number  ID `json:"number"`
+	}
+	_ = json.Unmarshal([]byte(`{"decimal":"11529408624707384402","number":11529408624707384402}`), &v)
+	fmt.Println(uint64(v.Decimal), uint64(v.Number))
+	// Output:
+	// {"id":"11529408624707384402"}
+	// 11529408624707384402 11529408624707384402
+}
@@ -0,0 +1,266 @@
+package snowflake
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"time"
+)
+
+var (
+	// ErrTimestampBitsTooSmall is returned when the number of bits for the timestamp is too small
+	ErrTimestampBitsTooSmall = errors.New("timestamp bits is too small")
+	// ErrSequenceBitsTooSmall is returned when the number of bits for the sequence is too small
+	ErrSequenceBitsTooSmall = errors.New("sequence bits is too small")
+	// ErrLayoutTooLarge is returned when the bits of a layout do not fit in 64 bits
+	ErrLayoutTooLarge = errors.New("layout is larger than 64 bits")
+	// ErrTimestampOverflow is returned when a timestamp does not fit in the timestamp bits of the layout
+	ErrTimestampOverflow = errors.New("timestamp overflows the timestamp bits")
+	// ErrSequenceTooLarge is returned when a sequence number does not fit in the sequence bits of the layout
+	ErrSequenceTooLarge = errors.New("sequence number is too large")
+	// ErrInvalidUnit is returned when the time unit of a layout is not a positive multiple of a millisecond
+	ErrInvalidUnit = errors.New("time unit is not a positive multiple of a millisecond")
+	// ErrInvalidBitOrder is returned when the bit order of a layout is unknown
+	ErrInvalidBitOrder = errors.New("invalid bit order")
+	// ErrTimestampRangeTooLarge is returned when the time range of the timestamp bits and unit of a layout does not fit
+	// in int64 milliseconds
+	ErrTimestampRangeTooLarge = errors.New("timestamp range does not fit in int64 milliseconds")
+)
+
+// BitOrder is the order of the machine ID and sequence fields below the timestamp
+type BitOrder int
+
+const (
+	// TimeMachineSequence places the machine ID above the sequence, as Twitter does
+	TimeMachineSequence BitOrder = iota
+	// TimeSequenceMachine places the sequence above the machine ID, as Sonyflake does
+	TimeSequenceMachine
+)
+
+// Layout describes how the bits of a snowflake ID are divided between the timestamp, the machine ID and the sequence.
+// The most significant bits hold the timestamp, followed by the machine ID and the sequence in the bit order, and the
+// optional extra fields in the least significant bits. The timestamp counts units of time since the epoch.
+type Layout struct {
+	Epoch time.Time
+	// Unit is the duration of one tick of the timestamp, it must be a multiple of a millisecond.
+	// The zero value is a millisecond.
+	Unit          time.Duration
+	TimestampBits uint64
+	MachineIDBits uint64
+	SequenceBits  uint64
+	Order         BitOrder
+	// NodeFields optionally divide the machine ID into named fields, from most to least significant.
+	// The bits of the fields add up to MachineIDBits, unused fields have zero bits.
+	NodeFields [MaxNodeFields]Field
+	// ExtraFields optionally embed named values, such as an entity type or a shard, below the sequence, from most to
+	// least significant. They take bits from the sequence or the machine ID, unused fields have zero bits.
+	// Below the sequence they keep the IDs of a generator unique and increasing.
+	ExtraFields [MaxExtraFields]Field
+}
+
+// DefaultLayout is the layout of a generator without options, it has 42 timestamp bits, 10 machine ID bits and 12
+// sequence bits with an epoch of 2024-03-01 00:00:00 CET
+var DefaultLayout = Layout{
+	Epoch:         time.UnixMilli(1709247600000).UTC(),
+	TimestampBits: 42,
+	MachineIDBits: 10,
+	SequenceBits:  12,
+}
+
+// The layouts of other snowflake implementations, use them with WithLayout to generate or decode compatible IDs
+var (
+	// TwitterLayout is the layout of Twitter IDs, 41 timestamp bits, 10 machine ID bits and 12 sequence bits with an
+	// epoch of 2010-11-04 01:42:54.657 UTC
+	TwitterLayout = Layout{
+		Epoch:         time.UnixMilli(1288834974657).UTC(),
+		TimestampBits: 41,
+		MachineIDBits: 10,
+		SequenceBits:  12,
+	}
+	// DiscordLayout is the layout of Discord IDs, 42 timestamp bits, 10 machine ID bits and 12 sequence bits with an
+	// epoch of 2015-01-01 00:00:00 UTC. The machine ID holds the 5 bit worker ID above the 5 bit process ID.
+	DiscordLayout = Layout{
+		Epoch:         time.UnixMilli(1420070400000).UTC(),
+		TimestampBits: 42,
+		MachineIDBits: 10,
+		SequenceBits:  12,
+	}
+	// SonyflakeLayout is the layout of Sonyflake IDs, 39 timestamp bits counting 10 milliseconds, 8 sequence bits and
+	// 16 machine ID bits with an epoch of 2014-09-01 00:00:00 UTC. The sequence is placed above the machine ID.
+	SonyflakeLayout = Layout{
+		Epoch:         time.UnixMilli(1409529600000).UTC(),
+		Unit:          10 * time.Millisecond,
+		TimestampBits: 39,
+		MachineIDBits: 16,
+		SequenceBits:  8,
+		Order:         TimeSequenceMachine,
+	}
+	// JavaScriptLayout fits IDs in 53 bits, so they are safe integers in JavaScript, 38 timestamp bits counting 10
+	// milliseconds, 5 machine ID bits and 10 sequence bits with the epoch of DefaultLayout. It lasts for 87 years and
+	// generates up to 102400 IDs per second per machine.
+	JavaScriptLayout = Layout{
+		Epoch:         time.UnixMilli(1709247600000).UTC(),
+		Unit:          10 * time.Millisecond,
+		TimestampBits: 38,
+		MachineIDBits: 5,
+		SequenceBits:  10,
+	}
+	// BwmarrinLayout is the default layout of github.com/bwmarrin/snowflake, it is the same as the Twitter layout
+	BwmarrinLayout = TwitterLayout
+	// GodruoyiLayout is the default layout of github.com/godruoyi/go-snowflake, 41 timestamp bits, 10 machine ID bits
+	// and 12 sequence bits with an epoch of 2008-11-10 23:00:00 UTC
+	GodruoyiLayout = Layout{
+		Epoch:         time.UnixMilli(1226358000000).UTC(),
+		TimestampBits: 41,
+		MachineIDBits: 10,
+		SequenceBits:  12,
+	}
+	// InfluxLayout is the layout of github.com/influxdata/snowflake, 42 timestamp bits, 10 machine ID bits and 12
+	// sequence bits with an epoch of 2017-04-09 00:00:00 UTC. Its string form is the Influx64 encoding of ID.String.
+	InfluxLayout = Layout{
+		Epoch:         time.UnixMilli(1491696000000).UTC(),
+		TimestampBits: 42,
+		MachineIDBits: 10,
+		SequenceBits:  12,
+	}
+)
+
+// Validate returns an error if the layout cannot hold snowflake IDs
+func (l Layout) Validate() error {
+	// The fields are validated first, as the bits of invalid fields may overflow the checks of the layout
+	if err := validateFields(l.NodeFields[:], ErrInvalidNodeFields); err != nil {
+		return err
+	}
+	if err := validateFields(l.ExtraFields[:], ErrInvalidExtraFields); err != nil {
+		return err
+	}
+	switch {
+	case l.MachineIDBits < 1:
+		return ErrMachineBitsTooSmall
+	case l.TimestampBits < 1:
+		return ErrTimestampBitsTooSmall
+	case l.TimestampBits >= 64:
+		return ErrLayoutTooLarge
+	case l.MachineIDBits >= 64-l.TimestampBits:
+		return ErrMachineBitsTooLarge
+	case l.SequenceBits < 1:
+		return ErrSequenceBitsTooSmall
+	case l.SequenceBits+l.extraBits() > 64-l.TimestampBits-l.MachineIDBits:
+		return ErrLayoutTooLarge
+	case l.Unit < 0 || l.Unit%time.Millisecond != 0:
+		return ErrInvalidUnit
+	case uint64(1)<<l.TimestampBits > math.MaxInt64/uint64(l.unitMillis()):
+		return ErrTimestampRangeTooLarge
+	case l.Order != TimeMachineSequence && l.Order != TimeSequenceMachine:
+		return ErrInvalidBitOrder
+	}
+	return l.validateNodeFields()
+}
+
+// Decode decodes a snowflake ID into its components
+func (l Layout) Decode(id ID) DecodedID {
+	timestamp := uint64(id) >> l.timestampShift() & mask(l.TimestampBits)
+	machineID := uint64(id) >> l.machineIDShift() & mask(l.MachineIDBits)
+	decoded := DecodedID{
+		ID:        uint64(id),
+		Timestamp: timestamp,
+		MachineID: machineID,
+		Sequence:  uint64(id) >> l.sequenceShift() & mask(l.SequenceBits),
+		Time:      l.timeOf(timestamp),
+	}
+	decodeFields(l.NodeFields[:], machineID, decoded.Node[:])
+	decodeFields(l.ExtraFields[:], uint64(id)&mask(l.extraBits()), decoded.Extra[:])
+	return decoded
+}
+
+// Compose returns the snowflake ID with the timestamp, machine ID and sequence number.
+// The timestamp is the number of units since the epoch.
+// Returns an error if a component does not fit in its bits.
+func (l Layout) Compose(timestamp, machineID, sequence uint64) (ID, error) {
+	if timestamp > mask(l.TimestampBits) {
+		return 0, ErrTimestampOverflow
+	}
+	if machineID > mask(l.MachineIDBits) {
+		return 0, ErrMachineIDTooLarge
+	}
+	if sequence > mask(l.SequenceBits) {
+		return 0, ErrSequenceTooLarge
+	}
+	return l.compose(timestamp, machineID, sequence), nil
+}
+
+// ComposeWithFields returns the snowflake ID with the timestamp, machine ID, sequence number and the values of the
+// extra fields. Returns an error if a component does not fit in its bits.
+func (l Layout) ComposeWithFields(timestamp, machineID, sequence uint64, extra ...uint64) (ID, error) {
+	id, err := l.Compose(timestamp, machineID, sequence)
+	if err != nil {
+		return 0, err
+	}
+	fields, err := l.Extra(extra...)
+	if err != nil {
+		return 0, err
+	}
+	return id | ID(fields), nil
+}
+
+// Time returns the time of the timestamp of a snowflake ID
+func (l Layout) Time(id ID) time.Time {
+	return l.timeOf(uint64(id) >> l.timestampShift() & mask(l.TimestampBits))
+}
+
+// Exhaustion returns the time at which the timestamp no longer fits in its bits.
+// A time beyond int64 milliseconds since the Unix epoch is returned as the latest time that fits.
+func (l Layout) Exhaustion() time.Time {
+	return l.timeOf(mask(l.TimestampBits) + 1)
+}
+
+// compose returns the snowflake ID with the components without checking that they fit
+func (l Layout) compose(timestamp, machineID, sequence uint64) ID {
+	return ID(timestamp<<l.timestampShift() | machineID<<l.machineIDShift() | sequence<<l.sequenceShift())
+}
+
+// timeOf returns the time of the timestamp, or the latest time in int64 milliseconds if the time does not fit
+func (l Layout) timeOf(timestamp uint64) time.Time {
+	epoch := l.Epoch.UnixMilli()
+	hi, offset := bits.Mul64(timestamp, uint64(l.unitMillis()))
+	if hi != 0 || offset > math.MaxInt64 || epoch > 0 && int64(offset) > math.MaxInt64-epoch {
+		return time.UnixMilli(math.MaxInt64).UTC()
+	}
+	return time.UnixMilli(epoch + int64(offset)).UTC()
+}
+
+// unitMillis returns the number of milliseconds in a unit of the timestamp
+func (l Layout) unitMillis() int64 {
+	if l.Unit == 0 {
+		return 1
+	}
+	return l.Unit.Milliseconds()
+}
+
+// bits returns the number of bits used by the layout
+func (l Layout) bits() uint64 {
+	return l.TimestampBits + l.MachineIDBits + l.SequenceBits + l.extraBits()
+}
+
+func (l Layout) timestampShift() uint64 {
+	return l.MachineIDBits + l.SequenceBits + l.extraBits()
+}
+
+func (l Layout) machineIDShift() uint64 {
+	if l.Order == TimeSequenceMachine {
+		return l.extraBits()
+	}
+	return l.SequenceBits + l.extraBits()
+}
+
+func (l Layout) sequenceShift() uint64 {
+	if l.Order == TimeSequenceMachine {
+		return l.MachineIDBits + l.extraBits()
+	}
+	return l.extraBits()
+}
+
+// mask returns a mask of the lower bits
+func mask(bits uint64) uint64 {
+	return 1<<bits - 1
+}
@@ -0,0 +1,381 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestLayout_Validate tests the Validate method of the Layout
+func TestLayout_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		want   error
+	}{
+		{name: "Default", layout: DefaultLayout},
+		{name: "Less than 64 bits", layout: Layout{TimestampBits: 41, MachineIDBits: 10, SequenceBits: 12}},
+		{name: "Machine bits too small", layout: Layout{TimestampBits: 42, SequenceBits: 12}, want: ErrMachineBitsTooSmall},
+		{name: "Timestamp bits too small", layout: Layout{MachineIDBits: 10, SequenceBits: 12}, want: ErrTimestampBitsTooSmall},
+		{name: "Machine bits too large", layout: Layout{TimestampBits: 42, MachineIDBits: 22, SequenceBits: 1}, want: ErrMachineBitsTooLarge},
+		{name: "Timestamp bits too large", layout: Layout{TimestampBits: 64, MachineIDBits: 1, SequenceBits: 1}, want: ErrLayoutTooLarge},
+		{name: "Largest timestamp range", layout: Layout{Unit: 1024 * time.Millisecond, TimestampBits: 52, MachineIDBits: 1, SequenceBits: 1}},
+		{name: "Timestamp range too large", layout: Layout{Unit: time.Second, TimestampBits: 62, MachineIDBits: 1, SequenceBits: 1}, want: ErrTimestampRangeTooLarge},
+		{name: "Timestamp range just too large", layout: Layout{Unit: 2048 * time.Millisecond, TimestampBits: 52, MachineIDBits: 1, SequenceBits: 1}, want: ErrTimestampRangeTooLarge},
+		{name: "Sequence bits too small", layout: Layout{TimestampBits: 42, MachineIDBits: 10}, want: ErrSequenceBitsTooSmall},
+		{name: "Too large", layout: Layout{TimestampBits: 42, MachineIDBits: 10, SequenceBits: 13}, want: ErrLayoutTooLarge},
+		{name: "Unit", layout: Layout{Unit: time.Second, TimestampBits: 42, MachineIDBits: 10, SequenceBits: 12}},
+		{name: "Unit negative", layout: Layout{Unit: -time.Millisecond, TimestampBits: 42, MachineIDBits: 10, SequenceBits: 12}, want: ErrInvalidUnit},
+		{name: "Bit order", layout: Layout{TimestampBits: 39, MachineIDBits: 16, SequenceBits: 8, Order: TimeSequenceMachine}},
+		{name: "Bit order invalid", layout: Layout{TimestampBits: 39, MachineIDBits: 16, SequenceBits: 8, Order: 2}, want: ErrInvalidBitOrder},
+		{name: "Unit not a multiple", layout: Layout{Unit: 1500 * time.Microsecond, TimestampBits: 42, MachineIDBits: 10, SequenceBits: 12}, want: ErrInvalidUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestLayout_Compose tests that the Compose method of the Layout round trips with Decode and rejects large components
+func TestLayout_Compose(t *testing.T) {
+	layout := Layout{Epoch: time.UnixMilli(1288834974657), TimestampBits: 41, MachineIDBits: 10, SequenceBits: 12}
+	id, err := layout.Compose(1656432460105-1288834974657, 378, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != 1541815603606036480 {
+		t.Errorf("expected 1541815603606036480, got %v", uint64(id))
+	}
+
+	want := DecodedID{
+		ID:        1541815603606036480,
+		Timestamp: 1656432460105 - 1288834974657,
+		MachineID: 378,
+		Sequence:  0,
+		Time:      time.UnixMilli(1656432460105).UTC(),
+	}
+	if got := layout.Decode(id); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := layout.Time(id); !got.Equal(want.Time) {
+		t.Errorf("got %v, want %v", got, want.Time)
+	}
+
+	max, err := layout.Compose(1<<41-1, 1<<10-1, 1<<12-1)
+	if err != nil || max != 1<<63-1 {
+		t.Errorf("got %v, %v, want %v", uint64(max), err, uint64(1<<63-1))
+	}
+
+	tests := []struct {
+		name                         string
+		timestamp, machine, sequence uint64
+		want                         error
+	}{
+		{name: "Timestamp", timestamp: 1 << 41, want: ErrTimestampOverflow},
+		{name: "MachineID", machine: 1 << 10, want: ErrMachineIDTooLarge},
+		{name: "Sequence", sequence: 1 << 12, want: ErrSequenceTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := layout.Compose(tt.timestamp, tt.machine, tt.sequence); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestGenerator_Layout tests that the generator creates IDs with its layout
+func TestGenerator_Layout(t *testing.T) {
+	layout := Layout{Epoch: time.UnixMilli(1288834974657), TimestampBits: 41, MachineIDBits: 10, SequenceBits: 12}
+	generator, err := NewGenerator(378, WithLayout(layout))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if generator.Layout() != layout {
+		t.Errorf("got %v, want %v", generator.Layout(), layout)
+	}
+	generator.timeFunc = func() uint64 {
+		return 1656432460105
+	}
+	for sequence := uint64(0); sequence < 3; sequence++ {
+		id, err := generator.NextID()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if want, _ := layout.Compose(1656432460105-1288834974657, 378, sequence); id != want {
+			t.Errorf("got %v, want %v", uint64(id), uint64(want))
+		}
+		if got := layout.Decode(id); got != generator.DecodeID(id) {
+			t.Errorf("got %v, want %v", got, generator.DecodeID(id))
+		}
+	}
+
+	generator, err = NewGenerator(0, WithLayout(layout), WithMachineIDBits(5))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := generator.Layout(); got.MachineIDBits != 5 || got.SequenceBits != 17 {
+		t.Errorf("expected 5 machine ID bits and 17 sequence bits, got %v and %v", got.MachineIDBits, got.SequenceBits)
+	}
+
+	if _, err = NewGenerator(0, WithLayout(Layout{})); !errors.Is(err, ErrMachineBitsTooSmall) {
+		t.Errorf("expected %v, got %v", ErrMachineBitsTooSmall, err)
+	}
+}
+
+// TestWithMachineIDBits_Width tests that the machine ID bits and the extra fields keep the width of the layout below the
+// timestamp, so the bits of a preset layout are not changed
+func TestWithMachineIDBits_Width(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		opts                                   []Option
+		timestampBits, machineIDBits, sequence uint64
+	}{
+		{name: "Twitter", opts: []Option{WithLayout(TwitterLayout), WithMachineIDBits(10)}, timestampBits: 41, machineIDBits: 10, sequence: 12},
+		{name: "Twitter 5 bits", opts: []Option{WithLayout(TwitterLayout), WithMachineIDBits(5)}, timestampBits: 41, machineIDBits: 5, sequence: 17},
+		{name: "Sonyflake", opts: []Option{WithLayout(SonyflakeLayout), WithMachineIDBits(16)}, timestampBits: 39, machineIDBits: 16, sequence: 8},
+		{name: "Twitter int64 safe", opts: []Option{WithLayout(TwitterLayout), WithInt64Safe(), WithMachineIDBits(8)}, timestampBits: 41, machineIDBits: 8, sequence: 14},
+		{name: "Twitter extra fields", opts: []Option{WithLayout(TwitterLayout), WithExtraFields(Field{Name: "type", Bits: 4})}, timestampBits: 41, machineIDBits: 10, sequence: 8},
+		{name: "Default", opts: []Option{WithMachineIDBits(8)}, timestampBits: 42, machineIDBits: 8, sequence: 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(0, tt.opts...)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			got := generator.Layout()
+			if got.TimestampBits != tt.timestampBits || got.MachineIDBits != tt.machineIDBits || got.SequenceBits != tt.sequence {
+				t.Errorf("expected %v, %v and %v bits, got %v, %v and %v", tt.timestampBits, tt.machineIDBits, tt.sequence,
+					got.TimestampBits, got.MachineIDBits, got.SequenceBits)
+			}
+		})
+	}
+}
+
+// TestGenerator_TimeUnit tests that the generator counts the timestamp in the unit of its layout
+func TestGenerator_TimeUnit(t *testing.T) {
+	generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithTimeUnit(10*time.Millisecond),
+		WithTimestampBits(41), WithDriftNoWait(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := Layout{Epoch: time.UnixMilli(0), Unit: 10 * time.Millisecond, TimestampBits: 41, MachineIDBits: 10, SequenceBits: 12}
+	if generator.Layout() != want {
+		t.Errorf("got %v, want %v", generator.Layout(), want)
+	}
+	generator.timeFunc = func() uint64 {
+		return 12345
+	}
+
+	id, err := generator.NextID()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	decoded := generator.DecodeID(id)
+	if decoded.Timestamp != 1234 || decoded.MachineID != 1 || decoded.Sequence != 0 {
+		t.Errorf("got %v, want timestamp 1234, machine ID 1 and sequence 0", decoded)
+	}
+	if !decoded.Time.Equal(time.UnixMilli(12340)) {
+		t.Errorf("got %v, want %v", decoded.Time, time.UnixMilli(12340))
+	}
+
+	// The drift of 20 milliseconds allows 2 ticks of 10 milliseconds ahead of the clock
+	count := 1
+	for _, err = generator.NextID(); err == nil; _, err = generator.NextID() {
+		count++
+	}
+	if !errors.Is(err, ErrOutOfSequence) {
+		t.Errorf("expected %v, got %v", ErrOutOfSequence, err)
+	}
+	if count != 3<<12 {
+		t.Errorf("expected %v ids, got %v", 3<<12, count)
+	}
+
+	if _, err = NewGenerator(1, WithTimestampBits(43)); !errors.Is(err, ErrLayoutTooLarge) {
+		t.Errorf("expected %v, got %v", ErrLayoutTooLarge, err)
+	}
+	if _, err = NewGenerator(1, WithTimeUnit(time.Microsecond)); !errors.Is(err, ErrInvalidUnit) {
+		t.Errorf("expected %v, got %v", ErrInvalidUnit, err)
+	}
+}
+
+// TestGenerator_DriftTicks tests that a drift is rounded up to ticks of the time unit, so a drift shorter than a tick
+// still allows a tick ahead of the clock
+func TestGenerator_DriftTicks(t *testing.T) {
+	tests := []struct {
+		drift time.Duration
+		ticks int
+	}{
+		{drift: 5 * time.Millisecond, ticks: 1},
+		{drift: 10 * time.Millisecond, ticks: 1},
+		{drift: 15 * time.Millisecond, ticks: 2},
+		{drift: 500 * time.Microsecond, ticks: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.drift.String(), func(t *testing.T) {
+			generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithTimeUnit(10*time.Millisecond),
+				WithDriftNoWait(tt.drift))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			generator.timeFunc = func() uint64 {
+				return 12345
+			}
+			count := 0
+			for _, err = generator.NextID(); err == nil; _, err = generator.NextID() {
+				count++
+			}
+			if !errors.Is(err, ErrOutOfSequence) {
+				t.Errorf("expected %v, got %v", ErrOutOfSequence, err)
+			}
+			if want := (tt.ticks + 1) << 12; count != want {
+				t.Errorf("expected %v ids, got %v", want, count)
+			}
+		})
+	}
+}
+
+// TestGenerator_BlockingNextID_TimeUnit tests that a blocking generator sleeps until the next tick of its unit
+func TestGenerator_BlockingNextID_TimeUnit(t *testing.T) {
+	generator, err := NewGenerator(1, WithTimeUnit(10*time.Millisecond), WithMachineIDBits(20))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var previous ID
+	for i := 0; i < 12; i++ {
+		id, err := generator.BlockingNextID(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if id <= previous {
+			t.Errorf("expected %v to be greater than %v", uint64(id), uint64(previous))
+		}
+		previous = id
+	}
+}
+
+// TestLayout_Presets tests the preset layouts with test vectors of the upstream formats
+func TestLayout_Presets(t *testing.T) {
+	tests := []struct {
+		name      string
+		layout    Layout
+		id        ID
+		timestamp uint64
+		machineID uint64
+		sequence  uint64
+		time      time.Time
+	}{
+		{
+			// A tweet ID with its timestamp and machine ID
+			name: "Twitter", layout: TwitterLayout, id: 1541815603606036480,
+			timestamp: 1656432460105 - 1288834974657, machineID: 378, sequence: 0,
+			time: time.UnixMilli(1656432460105),
+		},
+		{
+			// The example of the Discord API documentation, worker 1, process 0 and increment 7
+			name: "Discord", layout: DiscordLayout, id: 175928847299117063,
+			timestamp: 41944705796, machineID: 1<<5 | 0, sequence: 7,
+			time: time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC),
+		},
+		{
+			// Generated by github.com/sony/sonyflake v1.2.0 with machine ID 0x1234, Decompose returns the timestamp in
+			// 10 millisecond units, the machine ID and the sequence
+			name: "Sonyflake", layout: SonyflakeLayout, id: 642141427438129716,
+			timestamp: 38274611678, machineID: 0x1234, sequence: 3,
+			time: time.UnixMilli(1792275716780),
+		},
+		{
+			// JavaScriptLayout is not an upstream format, the vector is composed from its documented bits
+			name: "JavaScript", layout: JavaScriptLayout, id: 11796487171,
+			timestamp: 360000, machineID: 7, sequence: 3,
+			time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Generated by github.com/bwmarrin/snowflake v0.3.0 with node 378, ID.Time, ID.Node and ID.Step return the
+			// time, the machine ID and the sequence
+			name: "Bwmarrin", layout: BwmarrinLayout, id: 2111583518451015683,
+			timestamp: 1792275716780 - 1288834974657, machineID: 378, sequence: 3,
+			time: time.UnixMilli(1792275716780),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			want := DecodedID{ID: uint64(tt.id), Timestamp: tt.timestamp, MachineID: tt.machineID, Sequence: tt.sequence, Time: tt.time.UTC()}
+			if got := tt.layout.Decode(tt.id); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if id, err := tt.layout.Compose(tt.timestamp, tt.machineID, tt.sequence); err != nil || id != tt.id {
+				t.Errorf("got %v, %v, want %v", uint64(id), err, uint64(tt.id))
+			}
+
+			generator, err := NewGenerator(tt.machineID, WithLayout(tt.layout))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			generator.timeFunc = func() uint64 {
+				return uint64(tt.time.UnixMilli())
+			}
+			for sequence := uint64(0); sequence <= tt.sequence; sequence++ {
+				id, err := generator.NextID()
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if want, _ := tt.layout.Compose(tt.timestamp, tt.machineID, sequence); id != want {
+					t.Errorf("got %v, want %v", uint64(id), uint64(want))
+				}
+			}
+		})
+	}
+
+	// Discord documents the timestamp as the 42 bits above bit 22
+	if year := DiscordLayout.Exhaustion().Year(); year != 2154 {
+		t.Errorf("expected Discord IDs to be exhausted in 2154, got %v", year)
+	}
+}
+
+// ExampleLayout_Decode is an example of decoding an ID without a generator
+func ExampleLayout_Decode() {
+	layout := Layout{Epoch: time.UnixMilli(1288834974657), TimestampBits: 41, MachineIDBits: 10, SequenceBits: 12}
+	decoded := layout.Decode(1541815603606036480)
+	fmt.Println(decoded.MachineID, decoded.Sequence)
+	fmt.Println(layout.Time(1541815603606036480))
+	// Output:
+	// 378 0
+	// 2022-06-28 16:07:40.105 +0000 UTC
+}
+
+// TestLayout_Exhaustion tests the exhaustion time of layouts, a time beyond int64 milliseconds is the latest time
+func TestLayout_Exhaustion(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		want   time.Time
+	}{
+		{name: "Default", layout: DefaultLayout, want: time.UnixMilli(1709247600000 + 1<<42)},
+		{name: "Largest range", layout: Layout{Epoch: DefaultLayout.Epoch, Unit: 1024 * time.Millisecond, TimestampBits: 52},
+			want: time.UnixMilli(1709247600000 + 1<<62)},
+		{name: "Epoch beyond the range", layout: Layout{Epoch: time.UnixMilli(math.MaxInt64 - 1<<41), TimestampBits: 42},
+			want: time.UnixMilli(math.MaxInt64)},
+		{name: "Range overflows", layout: Layout{Unit: time.Second, TimestampBits: 62}, want: time.UnixMilli(math.MaxInt64)},
+		{name: "Range overflows uint64", layout: Layout{Unit: time.Hour, TimestampBits: 63}, want: time.UnixMilli(math.MaxInt64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.Exhaustion(); !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/base58"
+	"github.com/crosscode-nl/snowflake/internal/codecs/base62"
+	"github.com/crosscode-nl/snowflake/internal/codecs/base64"
+	"github.com/crosscode-nl/snowflake/internal/codecs/base64/influx"
+	"github.com/crosscode-nl/snowflake/internal/codecs/crockford"
+	"github.com/crosscode-nl/snowflake/internal/codecs/decimal"
+	"github.com/crosscode-nl/snowflake/internal/codecs/hex"
+	"github.com/crosscode-nl/snowflake/internal/codecs/lookup"
+)
+
+var (
+	// ErrInvalidLength is returned when an encoded ID does not have the length of its encoding
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrInvalidCharacter is returned when an encoded ID contains a character that is not part of its alphabet
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrOverflow is returned when an encoded ID carries more than 64 bits
+	ErrOverflow = errors.New("value overflows 64 bits")
+)
+
+// LengthError describes an encoded ID with the wrong length
+type LengthError struct {
+	Length   int
+	Expected int
+}
+
+// Error returns a description of the length error
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%v: got %d characters, expected %d", ErrInvalidLength, e.Length, e.Expected)
+}
+
+// Unwrap returns ErrInvalidLength
+func (e *LengthError) Unwrap() error {
+	return ErrInvalidLength
+}
+
+// CharacterError describes a character in an encoded ID that is not part of the alphabet
+type CharacterError struct {
+	Char     byte
+	Position int
+}
+
+// Error returns a description of the character error
+func (e *CharacterError) Error() string {
+	return fmt.Sprintf("%v %q at position %d", ErrInvalidCharacter, e.Char, e.Position)
+}
+
+// Unwrap returns ErrInvalidCharacter
+func (e *CharacterError) Unwrap() error {
+	return ErrInvalidCharacter
+}
+
+// OverflowError describes a character in an encoded ID that carries bits beyond the 64 bits of an ID
+type OverflowError struct {
+	Char     byte
+	Position int
+}
+
+// Error returns a description of the overflow error
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%v: character %q at position %d", ErrOverflow, e.Char, e.Position)
+}
+
+// Unwrap returns ErrOverflow
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// customTable fills t with the values of the bytes of s in the lookup map of a custom alphabet and returns it.
+// The parsers only read the entries of the bytes they parse, so the other entries are left out instead of iterating
+// the map on every call.
+func customTable(t *lookup.Table, s []byte, alphabetLookup AlphabetLookup) *lookup.Table {
+	m := alphabetLookup()
+	for _, c := range s {
+		t[c] = lookup.Invalid
+		if v, ok := m[c]; ok {
+			t[c] = byte(v)
+		}
+	}
+	return t
+}
+
+// checkCharacters returns an error for the first character of s that is not in the lookup table
+func checkCharacters(s []byte, t *lookup.Table) error {
+	if i := t.Check(s); i >= 0 {
+		return &CharacterError{Char: s[i], Position: i}
+	}
+	return nil
+}
+
+// ParseString parses a snowflake ID from a string, returning an error if the string is invalid
+func ParseString(s string) (ID, error) {
+	return ParseInflux64String(s)
+}
+
+// ParseBytes parses a snowflake ID from a byte slice, returning an error if the bytes are invalid
+func ParseBytes(s []byte) (ID, error) {
+	return ParseInflux64Bytes(s)
+}
+
+// ParseLowerHexString parses a snowflake ID from a lower case hex string, returning an error if the string is invalid
+func ParseLowerHexString(s string) (ID, error) {
+	var b [16]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseHex(&b, hex.LowerTable)
+}
+
+// ParseLowerHexBytes parses a snowflake ID from lower case hex bytes, returning an error if the bytes are invalid
+func ParseLowerHexBytes(s []byte) (ID, error) {
+	var b [16]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseHex(&b, hex.LowerTable)
+}
+
+// ParseUpperHexString parses a snowflake ID from an upper case hex string, returning an error if the string is invalid
+func ParseUpperHexString(s string) (ID, error) {
+	var b [16]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseHex(&b, hex.UpperTable)
+}
+
+// ParseUpperHexBytes parses a snowflake ID from upper case hex bytes, returning an error if the bytes are invalid
+func ParseUpperHexBytes(s []byte) (ID, error) {
+	var b [16]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseHex(&b, hex.UpperTable)
+}
+
+// ParseBase64String parses a snowflake ID from a base64 string, returning an error if the string is invalid
+func ParseBase64String(s string) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseBase64(&b, base64.StdTable)
+}
+
+// ParseBase64Bytes parses a snowflake ID from base64 bytes, returning an error if the bytes are invalid
+func ParseBase64Bytes(s []byte) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseBase64(&b, base64.StdTable)
+}
+
+// ParseBase64StringCustom parses a snowflake ID from a custom base64 string, returning an error if the string is
+// invalid. Each call builds the lookup map, use Parse with a codec from NewBase64Codec to parse many IDs.
+func ParseBase64StringCustom(s string, alphabetLookup AlphabetLookup) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	var t lookup.Table
+	return parseBase64(&b, customTable(&t, b[:], alphabetLookup))
+}
+
+// ParseBase64BytesCustom parses a snowflake ID from custom base64 bytes, returning an error if the bytes are invalid.
+// Each call builds the lookup map, use a codec from NewBase64Codec to parse many IDs.
+func ParseBase64BytesCustom(s []byte, alphabetLookup AlphabetLookup) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	var t lookup.Table
+	return parseBase64(&b, customTable(&t, b[:], alphabetLookup))
+}
+
+// ParseInflux64String parses a snowflake ID from an Influx style base64 string, returning an error if the string is invalid
+func ParseInflux64String(s string) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseInflux64(&b, influx.Table)
+}
+
+// ParseInflux64Bytes parses a snowflake ID from Influx style base64 bytes, returning an error if the bytes are invalid
+func ParseInflux64Bytes(s []byte) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseInflux64(&b, influx.Table)
+}
+
+// ParseInflux64StringCustom parses a snowflake ID from a custom Influx style base64 string, returning an error if the
+// string is invalid. Each call builds the lookup map, use Parse with a codec from NewInflux64Codec to parse many IDs.
+func ParseInflux64StringCustom(s string, alphabetLookup AlphabetLookup) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	var t lookup.Table
+	return parseInflux64(&b, customTable(&t, b[:], alphabetLookup))
+}
+
+// ParseInflux64BytesCustom parses a snowflake ID from custom Influx style base64 bytes, returning an error if the bytes
+// are invalid. Each call builds the lookup map, use a codec from NewInflux64Codec to parse many IDs.
+func ParseInflux64BytesCustom(s []byte, alphabetLookup AlphabetLookup) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	var t lookup.Table
+	return parseInflux64(&b, customTable(&t, b[:], alphabetLookup))
+}
+
+// ParseCrockfordString parses a snowflake ID from a Crockford base32 string, returning an error if the string is
+// invalid. Decoding is case-insensitive and the ambiguous characters I, L and O decode as 1, 1 and 0.
+func ParseCrockfordString(s string) (ID, error) {
+	var b [13]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseCrockford(&b)
+}
+
+// ParseCrockfordBytes parses a snowflake ID from Crockford base32 bytes, returning an error if the bytes are invalid.
+// Decoding is case-insensitive and the ambiguous characters I, L and O decode as 1, 1 and 0.
+func ParseCrockfordBytes(s []byte) (ID, error) {
+	var b [13]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseCrockford(&b)
+}
+
+// ParseBase58String parses a snowflake ID from a padded or short base58 string, returning an error if the string is
+// invalid
+func ParseBase58String(s string) (ID, error) {
+	var b [11]byte
+	if len(s) == 0 || len(s) > len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	return parseBase58(b[:copy(b[:], s)])
+}
+
+// ParseBase58Bytes parses a snowflake ID from padded or short base58 bytes, returning an error if the bytes are invalid
+func ParseBase58Bytes(s []byte) (ID, error) {
+	if len(s) == 0 || len(s) > 11 {
+		return 0, &LengthError{Length: len(s), Expected: 11}
+	}
+	return parseBase58(s)
+}
+
+// ParseBase62String parses a snowflake ID from a padded or short base62 string, returning an error if the string is
+// invalid
+func ParseBase62String(s string) (ID, error) {
+	var b [11]byte
+	if len(s) == 0 || len(s) > len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	return parseBase62(b[:copy(b[:], s)])
+}
+
+// ParseBase62Bytes parses a snowflake ID from padded or short base62 bytes, returning an error if the bytes are invalid
+func ParseBase62Bytes(s []byte) (ID, error) {
+	if len(s) == 0 || len(s) > 11 {
+		return 0, &LengthError{Length: len(s), Expected: 11}
+	}
+	return parseBase62(s)
+}
+
+// ParseDecimalString parses a snowflake ID from a decimal string of up to 20 digits, with or without leading zeros,
+// returning an error if the string is invalid. Signs are not accepted, use IDFromInt64 for signed integers.
+func ParseDecimalString(s string) (ID, error) {
+	var b [20]byte
+	if len(s) == 0 || len(s) > len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	return parseDecimal(b[:copy(b[:], s)])
+}
+
+// ParseDecimalBytes parses a snowflake ID from decimal bytes of up to 20 digits, with or without leading zeros,
+// returning an error if the bytes are invalid. Signs are not accepted, use IDFromInt64 for signed integers.
+func ParseDecimalBytes(s []byte) (ID, error) {
+	if len(s) == 0 || len(s) > 20 {
+		return 0, &LengthError{Length: len(s), Expected: 20}
+	}
+	return parseDecimal(s)
+}
+
+// parseURLBase64 parses a snowflake ID from a base64 string with the url alphabet
+func parseURLBase64(s []byte) (ID, error) {
+	var b [11]byte
+	if len(s) != len(b) {
+		return 0, &LengthError{Length: len(s), Expected: len(b)}
+	}
+	copy(b[:], s)
+	return parseBase64(&b, base64.UrlTable)
+}
+
+func parseHex(b *[16]byte, t *lookup.Table) (ID, error) {
+	if err := checkCharacters(b[:], t); err != nil {
+		return 0, err
+	}
+	return ID(hex.Decode(b, t)), nil
+}
+
+func parseBase64(b *[11]byte, t *lookup.Table) (ID, error) {
+	if err := checkCharacters(b[:], t); err != nil {
+		return 0, err
+	}
+	// The last character carries the final 4 bits in its upper bits, the lower 2 bits must be zero
+	if t[b[10]]&0x3 != 0 {
+		return 0, &OverflowError{Char: b[10], Position: 10}
+	}
+	return ID(base64.Decode(b, t)), nil
+}
+
+func parseInflux64(b *[11]byte, t *lookup.Table) (ID, error) {
+	if err := checkCharacters(b[:], t); err != nil {
+		return 0, err
+	}
+	// The first character carries only the 4 most significant bits
+	if t[b[0]] > 0xF {
+		return 0, &OverflowError{Char: b[0], Position: 0}
+	}
+	return ID(influx.Decode(b, t)), nil
+}
+
+func parseCrockford(b *[13]byte) (ID, error) {
+	if err := checkCharacters(b[:], crockford.Table); err != nil {
+		return 0, err
+	}
+	// The first character carries only the 4 most significant bits
+	if crockford.Table[b[0]] > 0xF {
+		return 0, &OverflowError{Char: b[0], Position: 0}
+	}
+	return ID(crockford.Decode(b, crockford.Table)), nil
+}
+
+func parseBase58(s []byte) (ID, error) {
+	if err := checkCharacters(s, base58.Table); err != nil {
+		return 0, err
+	}
+	n, overflow := base58.Decode(s, base58.Table)
+	if overflow >= 0 {
+		return 0, &OverflowError{Char: s[overflow], Position: overflow}
+	}
+	return ID(n), nil
+}
+
+func parseBase62(s []byte) (ID, error) {
+	if err := checkCharacters(s, base62.Table); err != nil {
+		return 0, err
+	}
+	n, overflow := base62.Decode(s, base62.Table)
+	if overflow >= 0 {
+		return 0, &OverflowError{Char: s[overflow], Position: overflow}
+	}
+	return ID(n), nil
+}
+
+func parseDecimal(s []byte) (ID, error) {
+	if err := checkCharacters(s, decimal.Table); err != nil {
+		return 0, err
+	}
+	n, overflow := decimal.Decode(s, decimal.Table)
+	if overflow >= 0 {
+		return 0, &OverflowError{Char: s[overflow], Position: overflow}
+	}
+	return ID(n), nil
+}
@@ -0,0 +1,200 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/crosscode-nl/snowflake/internal/codecs/base64"
+)
+
+// TestParse tests the Parse functions with valid and invalid input
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) (ID, error)
+		input string
+		want  ID
+		err   error
+	}{
+		{name: "String", parse: ParseString, input: "A00h0xA0ZHI", want: 0xA000B00F0A023452},
+		{name: "String max", parse: ParseString, input: "F~~~~~~~~~~", want: math.MaxUint64},
+		{name: "String too short", parse: ParseString, input: "A00h0xA0ZH", err: ErrInvalidLength},
+		{name: "String too long", parse: ParseString, input: "A00h0xA0ZHI0", err: ErrInvalidLength},
+		{name: "String invalid character", parse: ParseString, input: "A00h0xA0Z+I", err: ErrInvalidCharacter},
+		{name: "String overflow", parse: ParseString, input: "G~~~~~~~~~~", err: ErrOverflow},
+		{name: "LowerHex", parse: ParseLowerHexString, input: "a000b00f0a023452", want: 0xA000B00F0A023452},
+		{name: "LowerHex upper case", parse: ParseLowerHexString, input: "A000B00F0A023452", err: ErrInvalidCharacter},
+		{name: "LowerHex too short", parse: ParseLowerHexString, input: "a000b00f0a02345", err: ErrInvalidLength},
+		{name: "UpperHex", parse: ParseUpperHexString, input: "A000B00F0A023452", want: 0xA000B00F0A023452},
+		{name: "UpperHex lower case", parse: ParseUpperHexString, input: "a000b00f0a023452", err: ErrInvalidCharacter},
+		{name: "UpperHex too long", parse: ParseUpperHexString, input: "A000B00F0A0234520", err: ErrInvalidLength},
+		{name: "Base64", parse: ParseBase64String, input: "UjQCCg+wAKA", want: 0xA000B00F0A023452},
+		{name: "Base64 max", parse: ParseBase64String, input: "//////////8", want: math.MaxUint64},
+		{name: "Base64 invalid character", parse: ParseBase64String, input: "UjQCCg-wAKA", err: ErrInvalidCharacter},
+		{name: "Base64 overflow", parse: ParseBase64String, input: "//////////9", err: ErrOverflow},
+		{name: "Base64 empty", parse: ParseBase64String, input: "", err: ErrInvalidLength},
+		{name: "Crockford", parse: ParseCrockfordString, input: "A005G1W504D2J", want: 0xA000B00F0A023452},
+		{name: "Crockford lower case", parse: ParseCrockfordString, input: "a005g1w504d2j", want: 0xA000B00F0A023452},
+		{name: "Crockford ambiguous", parse: ParseCrockfordString, input: "OOOOOOOOOOOiL", want: 0x21},
+		{name: "Crockford invalid character", parse: ParseCrockfordString, input: "A005G1W504D2U", err: ErrInvalidCharacter},
+		{name: "Crockford overflow", parse: ParseCrockfordString, input: "G000000000000", err: ErrOverflow},
+		{name: "Crockford too short", parse: ParseCrockfordString, input: "A005G1W504D2", err: ErrInvalidLength},
+		{name: "Base58", parse: ParseBase58String, input: "TmE8u91MvdP", want: 0xA000B00F0A023452},
+		{name: "Base58 short", parse: ParseBase58String, input: "2", want: 1},
+		{name: "Base58 max", parse: ParseBase58String, input: "jpXCZedGfVQ", want: math.MaxUint64},
+		{name: "Base58 ambiguous", parse: ParseBase58String, input: "TmE8u9lMvdP", err: ErrInvalidCharacter},
+		{name: "Base58 overflow", parse: ParseBase58String, input: "jpXCZedGfVR", err: ErrOverflow},
+		{name: "Base58 empty", parse: ParseBase58String, input: "", err: ErrInvalidLength},
+		{name: "Base58 too long", parse: ParseBase58String, input: "111111111112", err: ErrInvalidLength},
+		{name: "Base62", parse: ParseBase62String, input: "DjgoSJw8iZe", want: 0xA000B00F0A023452},
+		{name: "Base62 short", parse: ParseBase62String, input: "1", want: 1},
+		{name: "Base62 max", parse: ParseBase62String, input: "LygHa16AHYF", want: math.MaxUint64},
+		{name: "Base62 invalid character", parse: ParseBase62String, input: "DjgoSJw8i_e", err: ErrInvalidCharacter},
+		{name: "Base62 overflow", parse: ParseBase62String, input: "LygHa16AHYG", err: ErrOverflow},
+		{name: "Base62 empty", parse: ParseBase62String, input: "", err: ErrInvalidLength},
+		{name: "Decimal", parse: ParseDecimalString, input: "11529408624707384402", want: 0xA000B00F0A023452},
+		{name: "Decimal short", parse: ParseDecimalString, input: "42", want: 42},
+		{name: "Decimal padded", parse: ParseDecimalString, input: "00000000000000000042", want: 42},
+		{name: "Decimal max", parse: ParseDecimalString, input: "18446744073709551615", want: math.MaxUint64},
+		{name: "Decimal sign", parse: ParseDecimalString, input: "-1", err: ErrInvalidCharacter},
+		{name: "Decimal overflow", parse: ParseDecimalString, input: "18446744073709551616", err: ErrOverflow},
+		{name: "Decimal empty", parse: ParseDecimalString, input: "", err: ErrInvalidLength},
+		{name: "Decimal too long", parse: ParseDecimalString, input: "000000000000000000042", err: ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", uint64(got), uint64(tt.want))
+			}
+		})
+	}
+}
+
+// TestParseCustom tests the custom alphabet Parse functions
+func TestParseCustom(t *testing.T) {
+	id, err := ParseBase64StringCustom("UjQCCg-wAKA", base64.UrlAlphabetLookup)
+	if err != nil || id != 0xA000B00F0A023452 {
+		t.Errorf("got %v, %v, want %v", uint64(id), err, uint64(0xA000B00F0A023452))
+	}
+	_, err = ParseBase64StringCustom("UjQCCg+wAKA", base64.UrlAlphabetLookup)
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected %v, got %v", ErrInvalidCharacter, err)
+	}
+	id, err = ParseInflux64StringCustom("P__________", base64.UrlAlphabetLookup)
+	if err != nil || id != math.MaxUint64 {
+		t.Errorf("got %v, %v, want %v", uint64(id), err, uint64(math.MaxUint64))
+	}
+	_, err = ParseInflux64StringCustom("Q__________", base64.UrlAlphabetLookup)
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("expected %v, got %v", ErrOverflow, err)
+	}
+}
+
+// TestParseBytes tests the Parse functions that take a byte slice
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func([]byte) (ID, error)
+		input string
+		want  ID
+		err   error
+	}{
+		{name: "Bytes", parse: ParseBytes, input: "A00h0xA0ZHI", want: 0xA000B00F0A023452},
+		{name: "Bytes overflow", parse: ParseBytes, input: "G~~~~~~~~~~", err: ErrOverflow},
+		{name: "LowerHex", parse: ParseLowerHexBytes, input: "a000b00f0a023452", want: 0xA000B00F0A023452},
+		{name: "LowerHex too short", parse: ParseLowerHexBytes, input: "a000b00f0a02345", err: ErrInvalidLength},
+		{name: "UpperHex", parse: ParseUpperHexBytes, input: "A000B00F0A023452", want: 0xA000B00F0A023452},
+		{name: "UpperHex too long", parse: ParseUpperHexBytes, input: "A000B00F0A0234520", err: ErrInvalidLength},
+		{name: "Base64", parse: ParseBase64Bytes, input: "UjQCCg+wAKA", want: 0xA000B00F0A023452},
+		{name: "Base64 too short", parse: ParseBase64Bytes, input: "UjQCCg+wAK", err: ErrInvalidLength},
+		{name: "Influx64", parse: ParseInflux64Bytes, input: "A00h0xA0ZHI", want: 0xA000B00F0A023452},
+		{name: "Influx64 too short", parse: ParseInflux64Bytes, input: "", err: ErrInvalidLength},
+		{name: "Crockford", parse: ParseCrockfordBytes, input: "A005G1W504D2J", want: 0xA000B00F0A023452},
+		{name: "Crockford too long", parse: ParseCrockfordBytes, input: "A005G1W504D2J0", err: ErrInvalidLength},
+		{name: "Base58", parse: ParseBase58Bytes, input: "TmE8u91MvdP", want: 0xA000B00F0A023452},
+		{name: "Base58 too long", parse: ParseBase58Bytes, input: "TmE8u91MvdP1", err: ErrInvalidLength},
+		{name: "Base62", parse: ParseBase62Bytes, input: "DjgoSJw8iZe", want: 0xA000B00F0A023452},
+		{name: "Base62 overflow", parse: ParseBase62Bytes, input: "LygHa16AHYG", err: ErrOverflow},
+		{name: "Decimal", parse: ParseDecimalBytes, input: "11529408624707384402", want: 0xA000B00F0A023452},
+		{name: "Decimal invalid character", parse: ParseDecimalBytes, input: "1152940862470738440x", err: ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.input))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", uint64(got), uint64(tt.want))
+			}
+		})
+	}
+
+	id, err := ParseBase64BytesCustom([]byte("UjQCCg-wAKA"), base64.UrlAlphabetLookup)
+	if err != nil || id != 0xA000B00F0A023452 {
+		t.Errorf("got %v, %v, want %v", uint64(id), err, uint64(0xA000B00F0A023452))
+	}
+	if _, err = ParseBase64BytesCustom([]byte("UjQCCg-wAK"), base64.UrlAlphabetLookup); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+	id, err = ParseInflux64BytesCustom([]byte("P__________"), base64.UrlAlphabetLookup)
+	if err != nil || id != math.MaxUint64 {
+		t.Errorf("got %v, %v, want %v", uint64(id), err, uint64(math.MaxUint64))
+	}
+	if _, err = ParseInflux64BytesCustom([]byte("P_________"), base64.UrlAlphabetLookup); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected %v, got %v", ErrInvalidLength, err)
+	}
+}
+
+// TestParse_ErrorDetails tests that the typed errors carry the details of the failure
+func TestParse_ErrorDetails(t *testing.T) {
+	_, err := ParseString("A00h0xA0Z+I")
+	var charErr *CharacterError
+	if !errors.As(err, &charErr) {
+		t.Fatalf("expected *CharacterError, got %T", err)
+	}
+	if charErr.Char != '+' || charErr.Position != 9 {
+		t.Errorf("expected '+' at position 9, got %q at position %d", charErr.Char, charErr.Position)
+	}
+
+	_, err = ParseLowerHexString("abc")
+	var lengthErr *LengthError
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("expected *LengthError, got %T", err)
+	}
+	if lengthErr.Length != 3 || lengthErr.Expected != 16 {
+		t.Errorf("expected length 3 and expected 16, got %d and %d", lengthErr.Length, lengthErr.Expected)
+	}
+
+	_, err = ParseString("z0000000000")
+	var overflowErr *OverflowError
+	if !errors.As(err, &overflowErr) {
+		t.Fatalf("expected *OverflowError, got %T", err)
+	}
+	if overflowErr.Char != 'z' || overflowErr.Position != 0 {
+		t.Errorf("expected 'z' at position 0, got %q at position %d", overflowErr.Char, overflowErr.Position)
+	}
+}
+
+// ExampleParseString is an example of the ParseString function
+func ExampleParseString() {
+	id, err := ParseString("A00h0xA0ZHI")
+	fmt.Println(uint64(id), err)
+	_, err = ParseString("A00h0xA0Z+I")
+	fmt.Println(err)
+	_, err = ParseString("A00h0xA0Z")
+	fmt.Println(err)
+	_, err = ParseString("G~~~~~~~~~~")
+	fmt.Println(err)
+	// Output:
+	// 11529408624707384402 <nil>
+	// invalid character '+' at position 9
+	// invalid length: got 9 characters, expected 11
+	// value overflows 64 bits: character 'G' at position 0
+}
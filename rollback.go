@@ -0,0 +1,95 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrClockMovedBackwards is returned when the clock moved backwards and the rollback policy does not allow it
+var ErrClockMovedBackwards = errors.New("clock moved backwards")
+
+// ClockSkewError describes a clock that moved backwards, it carries the skew
+type ClockSkewError struct {
+	Skew time.Duration
+}
+
+// Error returns a description of the clock skew
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("%v by %v", ErrClockMovedBackwards, e.Skew)
+}
+
+// Unwrap returns ErrClockMovedBackwards
+func (e *ClockSkewError) Unwrap() error {
+	return ErrClockMovedBackwards
+}
+
+// RollbackPolicy is what the generator does when the clock moves backwards
+type RollbackPolicy int
+
+const (
+	// RollbackContinue keeps generating IDs with the last timestamp until the clock catches up, until the sequence is
+	// exhausted. This is the default.
+	RollbackContinue RollbackPolicy = iota
+	// RollbackFail returns a ClockSkewError until the clock catches up
+	RollbackFail
+	// RollbackWait waits for the clock to catch up when the skew is within the tolerance, and returns a
+	// ClockSkewError otherwise. NextID blocks while it waits, BlockingNextID stops waiting when its context is
+	// canceled.
+	RollbackWait
+)
+
+// readClock returns the time of the clock in milliseconds and applies the rollback policy when the clock moved
+// backwards, a rollback wait is canceled with ctx. The highest and the last time are loaded before the clock is read,
+// so a time that a concurrent call read later is never mistaken for a rollback.
+func (g *Generator) readClock(ctx context.Context) (uint64, error) {
+	var waited time.Duration
+	for {
+		highest := g.clockHighest.Load()
+		last := g.clockLast.Load()
+		now := g.timeFunc()
+		if now != last {
+			g.clockLast.Store(now)
+		}
+		if now < last {
+			g.rollbacks.Add(1)
+		}
+		if now >= highest {
+			for highest < now && !g.clockHighest.CompareAndSwap(highest, now) {
+				highest = g.clockHighest.Load()
+			}
+			return now, nil
+		}
+
+		skew := time.Duration(highest-now) * time.Millisecond
+		switch {
+		case g.rollbackPolicy == RollbackContinue:
+			return now, nil
+		case g.rollbackPolicy == RollbackWait && waited+skew <= g.rollbackTolerance:
+			if err := g.waitFunc(ctx, skew); err != nil {
+				return 0, err
+			}
+			waited += skew
+		default:
+			return 0, &ClockSkewError{Skew: skew}
+		}
+	}
+}
+
+// waitContext waits for the duration, or until ctx is canceled
+func waitContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ClockRollbacks returns the number of times the generator saw the clock move backwards
+func (g *Generator) ClockRollbacks() uint64 {
+	return g.rollbacks.Load()
+}
@@ -0,0 +1,128 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWithClockRollback tests the rollback policies when the clock moves backwards by 5 milliseconds
+func TestWithClockRollback(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RollbackPolicy
+		wait    time.Duration
+		skew    time.Duration
+		waited  time.Duration
+		wantErr bool
+	}{
+		{name: "continue", policy: RollbackContinue},
+		{name: "fail", policy: RollbackFail, skew: 5 * time.Millisecond, wantErr: true},
+		{name: "wait within tolerance", policy: RollbackWait, wait: 10 * time.Millisecond, waited: 5 * time.Millisecond},
+		{name: "wait exact tolerance", policy: RollbackWait, wait: 5 * time.Millisecond, waited: 5 * time.Millisecond},
+		{name: "wait beyond tolerance", policy: RollbackWait, wait: 4 * time.Millisecond, skew: 5 * time.Millisecond, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithClockRollback(tt.policy, tt.wait))
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			now := uint64(1000)
+			var waited time.Duration
+			generator.timeFunc = func() uint64 {
+				return now
+			}
+			generator.waitFunc = func(_ context.Context, d time.Duration) error {
+				waited += d
+				now += uint64(d.Milliseconds())
+				return nil
+			}
+
+			first, err := generator.NextID()
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			now -= 5
+			id, err := generator.NextID()
+			if tt.wantErr {
+				var skewErr *ClockSkewError
+				if !errors.Is(err, ErrClockMovedBackwards) || !errors.As(err, &skewErr) || skewErr.Skew != tt.skew {
+					t.Errorf("expected a ClockSkewError with a skew of %v, got %v", tt.skew, err)
+				}
+			} else if err != nil || id <= first {
+				t.Errorf("expected an ID above %v, got %v and %v", first, id, err)
+			}
+			if waited != tt.waited {
+				t.Errorf("expected to wait %v, got %v", tt.waited, waited)
+			}
+			if generator.ClockRollbacks() != 1 {
+				t.Errorf("expected 1 rollback, got %v", generator.ClockRollbacks())
+			}
+
+			// The clock staying behind is the same rollback, catching up and moving back again is another
+			_, _ = generator.NextID()
+			now += 10
+			if _, err = generator.NextID(); err != nil {
+				t.Errorf("expected no error after the clock caught up, got %v", err)
+			}
+			now--
+			_, _ = generator.NextID()
+			if generator.ClockRollbacks() != 2 {
+				t.Errorf("expected 2 rollbacks, got %v", generator.ClockRollbacks())
+			}
+		})
+	}
+}
+
+// TestClockSkewError tests the message of ClockSkewError
+func TestClockSkewError(t *testing.T) {
+	err := &ClockSkewError{Skew: 1500 * time.Millisecond}
+	if err.Error() != "clock moved backwards by 1.5s" {
+		t.Errorf("expected \"clock moved backwards by 1.5s\", got %q", err.Error())
+	}
+}
+
+// TestWithClockRollback_Cancel tests that BlockingNextID stops waiting for the clock when its context is canceled
+func TestWithClockRollback_Cancel(t *testing.T) {
+	generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithClockRollback(RollbackWait, time.Hour))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	now := uint64(time.Hour.Milliseconds())
+	generator.timeFunc = func() uint64 {
+		return now
+	}
+	if _, err = generator.NextID(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	now -= uint64(time.Minute.Milliseconds())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err = generator.BlockingNextID(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to be canceled, it took %v", elapsed)
+	}
+}
+
+// TestWaitContext tests that waitContext waits for the duration unless the context is canceled
+func TestWaitContext(t *testing.T) {
+	start := time.Now()
+	if err := waitContext(context.Background(), time.Millisecond); err != nil || time.Since(start) < time.Millisecond {
+		t.Errorf("expected to wait 1ms without error, got %v after %v", err, time.Since(start))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := waitContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
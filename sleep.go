@@ -0,0 +1,61 @@
+package snowflake
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SleepStrategy waits for the delay until the next tick of the timestamp when the sequence is exhausted.
+// time.Sleep is the default strategy, it is cheap but may overshoot the delay by tens of microseconds or more.
+type SleepStrategy func(delay time.Duration)
+
+// SpinSleep waits for the delay in a busy loop, it is exact but keeps a CPU busy while waiting
+func SpinSleep(delay time.Duration) {
+	deadline := time.Now().Add(delay)
+	for time.Now().Before(deadline) {
+	}
+}
+
+// HybridSleep returns a strategy that sleeps for the delay minus spin, and waits for the rest in a busy loop.
+// spin should be a bit larger than the overshoot of time.Sleep on the system.
+func HybridSleep(spin time.Duration) SleepStrategy {
+	return func(delay time.Duration) {
+		deadline := time.Now().Add(delay)
+		if delay > spin {
+			time.Sleep(delay - spin)
+		}
+		for time.Now().Before(deadline) {
+		}
+	}
+}
+
+// AdaptiveSleep returns a hybrid strategy that measures how much time.Sleep overshoots, and sleeps for the delay minus
+// the average overshoot before waiting for the rest in a busy loop
+func AdaptiveSleep() SleepStrategy {
+	return adaptiveSleep(time.Now, time.Sleep)
+}
+
+// adaptiveSleep returns AdaptiveSleep with the clock and the sleep function
+func adaptiveSleep(now func() time.Time, sleep func(time.Duration)) SleepStrategy {
+	var overshoot atomic.Int64
+	return func(delay time.Duration) {
+		start := now()
+		deadline := start.Add(delay)
+		average := overshoot.Load()
+		if target := delay - time.Duration(average); target > 0 {
+			sleep(target)
+			// The moving average follows an eighth of each new measurement. A stall longer than the delay, such as a
+			// garbage collection, counts as an overshoot of the delay.
+			measured := int64(now().Sub(start) - target)
+			if measured > int64(delay) {
+				measured = int64(delay)
+			}
+			overshoot.Store(average + (measured-average)/8)
+		} else {
+			// Without sleeping there is nothing to measure, the average decays so that sleeping resumes
+			overshoot.Store(average - average/8)
+		}
+		for now().Before(deadline) {
+		}
+	}
+}
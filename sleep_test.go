@@ -0,0 +1,110 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSleepStrategies tests that the strategies wait at least the delay
+func TestSleepStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy SleepStrategy
+	}{
+		{name: "time.Sleep", strategy: time.Sleep},
+		{name: "SpinSleep", strategy: SpinSleep},
+		{name: "HybridSleep", strategy: HybridSleep(200 * time.Microsecond)},
+		{name: "HybridSleep longer spin than delay", strategy: HybridSleep(time.Second)},
+		{name: "AdaptiveSleep", strategy: AdaptiveSleep()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, delay := range []time.Duration{0, 100 * time.Microsecond, time.Millisecond, 2 * time.Millisecond} {
+				start := time.Now()
+				tt.strategy(delay)
+				if elapsed := time.Since(start); elapsed < delay {
+					t.Errorf("expected to wait at least %v, waited %v", delay, elapsed)
+				}
+			}
+		})
+	}
+}
+
+// TestWithTimeSource tests that a generator reads and waits for the ticks of its time source and sleep strategy
+func TestWithTimeSource(t *testing.T) {
+	now := uint64(1000)
+	var slept time.Duration
+	generator, err := NewGenerator(1, WithEpoch(time.UnixMilli(0)), WithMachineIDBits(21),
+		WithTimeSource(func() uint64 {
+			return now
+		}),
+		WithSleepStrategy(func(delay time.Duration) {
+			slept += delay
+			now += uint64(delay.Milliseconds())
+		}))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	// 1 sequence bit remains, so the sequence is exhausted after 2 IDs in a tick
+	for i := 0; i < 6; i++ {
+		id, err := generator.BlockingNextID(context.Background())
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+		if decoded := generator.DecodeID(id); decoded.Timestamp != uint64(1000+i/2) {
+			t.Errorf("ID %d: expected timestamp %v, got %v", i, 1000+i/2, decoded.Timestamp)
+		}
+	}
+	if slept != 2*time.Millisecond {
+		t.Errorf("expected to sleep 2ms, slept %v", slept)
+	}
+}
+
+// TestWithTimeSource_Nil tests that nil options keep the defaults
+func TestWithTimeSource_Nil(t *testing.T) {
+	generator, err := NewGenerator(1, WithTimeSource(nil), WithSleepStrategy(nil))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if generator.timeSource || generator.timeFunc == nil || generator.sleepStrategy == nil {
+		t.Errorf("expected the default time source and sleep strategy")
+	}
+}
+
+// TestAdaptiveSleep_Stall tests that the adaptive strategy resumes sleeping after a stall of the sleep function
+func TestAdaptiveSleep_Stall(t *testing.T) {
+	var current time.Time
+	now := func() time.Time {
+		// Every reading of the clock takes a microsecond, so the busy loop ends
+		current = current.Add(time.Microsecond)
+		return current
+	}
+	sleeps := 0
+	overshoot := 50 * time.Microsecond
+	strategy := adaptiveSleep(now, func(d time.Duration) {
+		sleeps++
+		current = current.Add(d + overshoot)
+	})
+
+	overshoot = 20 * time.Millisecond
+	strategy(time.Millisecond)
+	overshoot = 50 * time.Microsecond
+	if sleeps != 1 {
+		t.Fatalf("expected 1 sleep, got %v", sleeps)
+	}
+	for i := 0; i < 100; i++ {
+		start := current
+		strategy(time.Millisecond)
+		if elapsed := current.Sub(start); elapsed < time.Millisecond {
+			t.Errorf("expected to wait at least 1ms, waited %v", elapsed)
+		}
+	}
+	if sleeps < 90 {
+		t.Errorf("expected to sleep again after the stall, slept %v times in 100 waits", sleeps-1)
+	}
+}
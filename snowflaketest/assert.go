@@ -0,0 +1,51 @@
+package snowflaketest
+
+import (
+	"testing"
+
+	"github.com/crosscode-nl/snowflake"
+)
+
+// AssertUnique reports an error for every ID that occurs earlier in the slice, it returns whether all IDs are unique
+func AssertUnique(tb testing.TB, ids []snowflake.ID) bool {
+	tb.Helper()
+	unique := true
+	seen := make(map[snowflake.ID]int, len(ids))
+	for i, id := range ids {
+		if first, ok := seen[id]; ok {
+			tb.Errorf("snowflaketest: ID %d at index %d duplicates index %d", id, i, first)
+			unique = false
+			continue
+		}
+		seen[id] = i
+	}
+	return unique
+}
+
+// AssertMonotonic reports an error for every ID that is not larger than the ID before it, it returns whether the IDs
+// are strictly increasing
+func AssertMonotonic(tb testing.TB, ids []snowflake.ID) bool {
+	tb.Helper()
+	monotonic := true
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			tb.Errorf("snowflaketest: ID %d at index %d is not larger than ID %d at index %d", ids[i], i, ids[i-1], i-1)
+			monotonic = false
+		}
+	}
+	return monotonic
+}
+
+// AssertMachineID reports an error for every ID that does not have the machine ID in the layout, it returns whether all
+// IDs have the machine ID
+func AssertMachineID(tb testing.TB, layout snowflake.Layout, ids []snowflake.ID, machineID uint64) bool {
+	tb.Helper()
+	matches := true
+	for i, id := range ids {
+		if got := layout.Decode(id).MachineID; got != machineID {
+			tb.Errorf("snowflaketest: ID %d at index %d has machine ID %d, expected %d", id, i, got, machineID)
+			matches = false
+		}
+	}
+	return matches
+}
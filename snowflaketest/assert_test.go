@@ -0,0 +1,58 @@
+package snowflaketest
+
+import (
+	"testing"
+
+	"github.com/crosscode-nl/snowflake"
+)
+
+// TestAssertions tests that the assertions report every offending ID
+func TestAssertions(t *testing.T) {
+	layout := snowflake.DefaultLayout
+	id := func(machineID, sequence uint64) snowflake.ID {
+		id, err := layout.Compose(1, machineID, sequence)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return id
+	}
+
+	tests := []struct {
+		name          string
+		ids           []snowflake.ID
+		wantUnique    int
+		wantMonotonic int
+		wantMachineID int
+	}{
+		{name: "empty"},
+		{name: "valid", ids: []snowflake.ID{id(5, 0), id(5, 1), id(5, 2)}},
+		{name: "duplicates", ids: []snowflake.ID{id(5, 0), id(5, 0), id(5, 1), id(5, 0)}, wantUnique: 2,
+			wantMonotonic: 2},
+		{name: "decreasing", ids: []snowflake.ID{id(5, 2), id(5, 1), id(5, 3)}, wantMonotonic: 1},
+		{name: "other machine", ids: []snowflake.ID{id(5, 0), id(6, 1), id(4, 2)}, wantMonotonic: 1,
+			wantMachineID: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := []struct {
+				name   string
+				assert func(tb testing.TB) bool
+				want   int
+			}{
+				{name: "AssertUnique", assert: func(tb testing.TB) bool { return AssertUnique(tb, tt.ids) },
+					want: tt.wantUnique},
+				{name: "AssertMonotonic", assert: func(tb testing.TB) bool { return AssertMonotonic(tb, tt.ids) },
+					want: tt.wantMonotonic},
+				{name: "AssertMachineID", assert: func(tb testing.TB) bool { return AssertMachineID(tb, layout, tt.ids, 5) },
+					want: tt.wantMachineID},
+			}
+			for _, a := range assertions {
+				r := &recorder{TB: t}
+				ok := a.assert(r)
+				if len(r.errors) != a.want || ok != (a.want == 0) {
+					t.Errorf("%s: expected %v errors, got %v and %v: %v", a.name, a.want, ok, len(r.errors), r.errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Package snowflaketest provides a fake clock, a deterministic generator and assertions to test code that uses
+// snowflake IDs without depending on real time.
+package snowflaketest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crosscode-nl/snowflake"
+)
+
+// Clock is a fake clock that only moves when it is advanced, set or slept on. It is safe for concurrent use.
+// Its Now method is a snowflake.TimeFunc and its Sleep method is a snowflake.SleepStrategy.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a fake clock at the time
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time of the clock in milliseconds since the Unix epoch
+func (c *Clock) Now() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return uint64(c.now.UnixMilli())
+}
+
+// Time returns the time of the clock
+func (c *Clock) Time() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the duration, a negative duration moves it backwards
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set sets the clock to the time
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Sleep advances the clock by the delay instead of waiting
+func (c *Clock) Sleep(delay time.Duration) {
+	c.Advance(delay)
+}
+
+// NewGenerator returns a generator that reads the time from a fake clock, which starts at the epoch of the layout of
+// the generator. BlockingNextID advances the clock instead of sleeping, so the IDs only depend on the calls made.
+// The options are applied after the fake clock, it fails the test if they are invalid.
+func NewGenerator(tb testing.TB, machineID uint64, opts ...snowflake.Option) (*snowflake.Generator, *Clock) {
+	tb.Helper()
+	clock := NewClock(time.UnixMilli(0))
+	opts = append([]snowflake.Option{snowflake.WithTimeSource(clock.Now), snowflake.WithSleepStrategy(clock.Sleep)},
+		opts...)
+	generator, err := snowflake.NewGenerator(machineID, opts...)
+	if err != nil {
+		tb.Fatalf("snowflaketest: cannot create generator: %v", err)
+		return nil, nil
+	}
+	clock.Set(generator.Layout().Epoch)
+	return generator, clock
+}
@@ -0,0 +1,122 @@
+package snowflaketest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crosscode-nl/snowflake"
+)
+
+// recorder is a testing.TB that records errors instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+// TestClock tests that the clock only moves when it is advanced, set or slept on
+func TestClock(t *testing.T) {
+	clock := NewClock(time.UnixMilli(1000))
+	var timeFunc snowflake.TimeFunc = clock.Now
+	var strategy snowflake.SleepStrategy = clock.Sleep
+
+	steps := []struct {
+		name string
+		step func()
+		want uint64
+	}{
+		{name: "start", step: func() {}, want: 1000},
+		{name: "advance", step: func() { clock.Advance(5 * time.Millisecond) }, want: 1005},
+		{name: "advance backwards", step: func() { clock.Advance(-2 * time.Millisecond) }, want: 1003},
+		{name: "sleep", step: func() { strategy(time.Second) }, want: 2003},
+		{name: "sub millisecond", step: func() { clock.Advance(500 * time.Microsecond) }, want: 2003},
+		{name: "set", step: func() { clock.Set(time.UnixMilli(42)) }, want: 42},
+	}
+	for _, tt := range steps {
+		tt.step()
+		if got := timeFunc(); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+		if got := uint64(clock.Time().UnixMilli()); got != tt.want {
+			t.Errorf("%s: expected time %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestNewGenerator tests that the generator starts at the epoch of its layout and advances the clock when it blocks
+func TestNewGenerator(t *testing.T) {
+	generator, clock := NewGenerator(t, 3, snowflake.WithLayout(snowflake.SonyflakeLayout))
+	if !clock.Time().Equal(snowflake.SonyflakeLayout.Epoch) {
+		t.Errorf("expected the clock at the epoch %v, got %v", snowflake.SonyflakeLayout.Epoch, clock.Time())
+	}
+
+	ids := make([]snowflake.ID, 600)
+	for i := range ids {
+		id, err := generator.BlockingNextID(context.Background())
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+		ids[i] = id
+	}
+	AssertUnique(t, ids)
+	AssertMonotonic(t, ids)
+	AssertMachineID(t, snowflake.SonyflakeLayout, ids, 3)
+
+	// 8 sequence bits give 256 IDs per tick of 10ms
+	if want := snowflake.SonyflakeLayout.Epoch.Add(20 * time.Millisecond); !clock.Time().Equal(want) {
+		t.Errorf("expected the clock at %v, got %v", want, clock.Time())
+	}
+	if last := generator.DecodeID(ids[599]); last.Timestamp != 2 || last.Sequence != 88 {
+		t.Errorf("expected timestamp 2 and sequence 88, got %v and %v", last.Timestamp, last.Sequence)
+	}
+
+	clock.Advance(-5 * time.Millisecond)
+	if _, err := generator.NextID(); err != nil {
+		t.Errorf("expected the default rollback policy to continue, got %v", err)
+	}
+}
+
+// TestNewGenerator_Error tests that invalid options fail the test
+func TestNewGenerator_Error(t *testing.T) {
+	r := &recorder{TB: t}
+	generator, clock := NewGenerator(r, 1, snowflake.WithMachineIDBits(0))
+	if generator != nil || clock != nil || !r.fatal || len(r.errors) != 1 {
+		t.Errorf("expected a fatal error, got %v", r.errors)
+	}
+	if _, err := snowflake.NewGenerator(1, snowflake.WithMachineIDBits(0)); !errors.Is(err, snowflake.ErrMachineBitsTooSmall) {
+		t.Errorf("expected ErrMachineBitsTooSmall, got %v", err)
+	}
+}
+
+// ExampleClock is an example of a generator that reads a fake clock, NewGenerator does the same in tests
+func ExampleClock() {
+	clock := NewClock(time.UnixMilli(367597485448))
+	generator, err := snowflake.NewGenerator(1, snowflake.WithEpoch(time.UnixMilli(0)),
+		snowflake.WithTimeSource(clock.Now), snowflake.WithSleepStrategy(clock.Sleep))
+	if err != nil {
+		panic(err)
+	}
+	id, _ := generator.NextID()
+	fmt.Println(id.DecimalString())
+	clock.Advance(time.Millisecond)
+	id, _ = generator.NextID()
+	fmt.Println(generator.DecodeID(id).Timestamp)
+	// Output:
+	// 1541815603604492288
+	// 367597485449
+}
@@ -0,0 +1,168 @@
+package snowflake
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrUnsupportedScanType is returned when a database value cannot be scanned into an ID
+var ErrUnsupportedScanType = errors.New("unsupported scan type")
+
+// Value implements driver.Valuer, the ID is stored as a signed 64-bit integer for BIGINT columns.
+// The bits of the ID are reinterpreted as an int64, so IDs above math.MaxInt64 are stored as negative numbers.
+// Scan reverses this mapping, so every ID survives a round trip through the database.
+func (id ID) Value() (driver.Value, error) {
+	return id.Int64(), nil
+}
+
+// Scan implements sql.Scanner.
+// An int64 is reinterpreted as the bits of the ID, the reverse of Value.
+// A string or []byte is parsed as a decimal, as drivers such as MySQL return BIGINT columns as text, a negative number
+// is reinterpreted as the bits of the ID. Use a wrapper type such as Influx64ID for the strings of a CHAR column.
+// NULL is rejected, use NullID for nullable columns.
+func (id *ID) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*id = IDFromInt64(v)
+	case uint64:
+		*id = ID(v)
+	case string:
+		parsed, err := scanText([]byte(v))
+		if err != nil {
+			return err
+		}
+		*id = parsed
+	case []byte:
+		parsed, err := scanText(v)
+		if err != nil {
+			return err
+		}
+		*id = parsed
+	default:
+		return fmt.Errorf("%w: cannot scan %T into ID", ErrUnsupportedScanType, src)
+	}
+	return nil
+}
+
+// scanText parses a BIGINT database value that was returned as text, a negative decimal is the int64 stored by Value
+func scanText(s []byte) (ID, error) {
+	if len(s) > 0 && s[0] == '-' {
+		v, err := strconv.ParseInt(string(s), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return IDFromInt64(v), nil
+	}
+	return parseDecimalForm(s)
+}
+
+// NullID is a snowflake ID that may be NULL, it implements sql.Scanner and driver.Valuer for nullable columns
+type NullID struct {
+	ID    ID
+	Valid bool // Valid is true if ID is not NULL
+}
+
+// Value implements driver.Valuer, it returns nil when the ID is not valid
+func (n NullID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ID.Value()
+}
+
+// Scan implements sql.Scanner, NULL results in an invalid NullID
+func (n *NullID) Scan(src any) error {
+	if src == nil {
+		n.ID, n.Valid = 0, false
+		return nil
+	}
+	if err := n.ID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// scanString returns the text of a database value for the wrapper types of CHAR columns
+func scanString(src any) ([]byte, error) {
+	switch v := src.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: cannot scan %T into a string ID", ErrUnsupportedScanType, src)
+}
+
+// Value implements driver.Valuer, the ID is stored as its Influx64 string for CHAR columns
+func (id Influx64ID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, it parses the Influx64 string of a CHAR column
+func (id *Influx64ID) Scan(src any) error {
+	text, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer, the ID is stored as its lower case hex string for CHAR columns
+func (id LowerHexID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, it parses the lower case hex string of a CHAR column
+func (id *LowerHexID) Scan(src any) error {
+	text, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer, the ID is stored as its upper case hex string for CHAR columns
+func (id UpperHexID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, it parses the upper case hex string of a CHAR column
+func (id *UpperHexID) Scan(src any) error {
+	text, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer, the ID is stored as its base64 string for CHAR columns
+func (id Base64ID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, it parses the base64 string of a CHAR column
+func (id *Base64ID) Scan(src any) error {
+	text, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer, the ID is stored as its base64 string with the url alphabet for CHAR columns
+func (id URLBase64ID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, it parses the base64 string with the url alphabet of a CHAR column
+func (id *URLBase64ID) Scan(src any) error {
+	text, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText(text)
+}
@@ -0,0 +1,162 @@
+package snowflake
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*ID)(nil)
+	_ driver.Valuer = ID(0)
+	_ sql.Scanner   = (*NullID)(nil)
+	_ driver.Valuer = NullID{}
+	_ sql.Scanner   = (*Influx64ID)(nil)
+	_ driver.Valuer = Influx64ID(0)
+)
+
+// TestID_Value tests the mapping of IDs to signed 64-bit integers
+func TestID_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		id   ID
+		want int64
+	}{
+		{name: "zero", id: 0, want: 0},
+		{name: "max int64", id: math.MaxInt64, want: math.MaxInt64},
+		{name: "above max int64", id: math.MaxInt64 + 1, want: math.MinInt64},
+		{name: "max uint64", id: math.MaxUint64, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.id.Value()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if v != tt.want {
+				t.Errorf("got %v, want %v", v, tt.want)
+			}
+			var id ID
+			if err = id.Scan(v); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if id != tt.id {
+				t.Errorf("round trip got %v, want %v", uint64(id), uint64(tt.id))
+			}
+		})
+	}
+}
+
+// TestID_Scan tests scanning the supported database types into an ID
+func TestID_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want ID
+		err  error
+	}{
+		{name: "int64", src: int64(-1), want: math.MaxUint64},
+		{name: "uint64", src: uint64(math.MaxUint64), want: math.MaxUint64},
+		{name: "decimal bytes", src: []byte("1541815603606036480"), want: 1541815603606036480},
+		{name: "decimal bytes of 11 digits", src: []byte("12345678901"), want: 12345678901},
+		{name: "decimal string", src: "1541815603606036480", want: 1541815603606036480},
+		{name: "zero padded decimal", src: "00000000000000000064", want: 64},
+		{name: "decimal overflow", src: []byte("18446744073709551616"), err: ErrOverflow},
+		{name: "negative decimal bytes", src: []byte("-1"), want: math.MaxUint64},
+		{name: "negative decimal string", src: "-9223372036854775808", want: 1 << 63},
+		{name: "invalid negative decimal", src: []byte("-1x"), err: strconv.ErrSyntax},
+		{name: "negative decimal out of range", src: "-9223372036854775809", err: strconv.ErrRange},
+		// The strings of a CHAR column are scanned with a wrapper type, they are never guessed from their content
+		{name: "Influx64 string", src: "A00h0xA0ZHI", err: ErrInvalidCharacter},
+		{name: "Influx64 bytes", src: []byte("A00h0xA0ZHI"), err: ErrInvalidCharacter},
+		{name: "Influx64 string of digits", src: "00000000010", err: ErrInvalidLength},
+		{name: "hex", src: "a000b00f0a023452", err: ErrInvalidCharacter},
+		{name: "empty", src: []byte{}, err: ErrInvalidLength},
+		{name: "float64", src: 1.5, err: ErrUnsupportedScanType},
+		{name: "nil", src: nil, err: ErrUnsupportedScanType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id ID
+			err := id.Scan(tt.src)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if id != tt.want {
+				t.Errorf("got %v, want %v", uint64(id), uint64(tt.want))
+			}
+		})
+	}
+}
+
+// TestNullID tests scanning and valuing a nullable ID
+func TestNullID(t *testing.T) {
+	var n NullID
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("expected invalid NullID without error, got %v, %v", n, err)
+	}
+	v, err := n.Value()
+	if err != nil || v != nil {
+		t.Errorf("expected nil value, got %v, %v", v, err)
+	}
+
+	if err = n.Scan(int64(42)); err != nil || !n.Valid || n.ID != 42 {
+		t.Errorf("expected valid NullID 42, got %v, %v", n, err)
+	}
+	v, err = n.Value()
+	if err != nil || v != int64(42) {
+		t.Errorf("expected 42, got %v, %v", v, err)
+	}
+
+	if err = n.Scan(1.5); !errors.Is(err, ErrUnsupportedScanType) || n.Valid {
+		t.Errorf("expected invalid NullID with error, got %v, %v", n, err)
+	}
+}
+
+// TestWrapperTypes_SQL tests that the wrapper types store and scan their strings for CHAR columns
+func TestWrapperTypes_SQL(t *testing.T) {
+	const id = ID(0xA000B00F0A023452)
+	tests := []struct {
+		name    string
+		valuer  driver.Valuer
+		scanner sql.Scanner
+		want    string
+	}{
+		{name: "Influx64ID", valuer: Influx64ID(id), scanner: new(Influx64ID), want: "A00h0xA0ZHI"},
+		{name: "LowerHexID", valuer: LowerHexID(id), scanner: new(LowerHexID), want: "a000b00f0a023452"},
+		{name: "UpperHexID", valuer: UpperHexID(id), scanner: new(UpperHexID), want: "A000B00F0A023452"},
+		{name: "Base64ID", valuer: Base64ID(id), scanner: new(Base64ID), want: "UjQCCg+wAKA"},
+		{name: "URLBase64ID", valuer: URLBase64ID(id), scanner: new(URLBase64ID), want: "UjQCCg-wAKA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.valuer.Value()
+			if err != nil || v != tt.want {
+				t.Errorf("Value() = %v, %v, want %v", v, err, tt.want)
+			}
+			for _, src := range []any{tt.want, []byte(tt.want)} {
+				if err = tt.scanner.Scan(src); err != nil {
+					t.Errorf("Scan(%v) = %v, want no error", src, err)
+				}
+				if got, _ := tt.scanner.(driver.Valuer).Value(); got != tt.want {
+					t.Errorf("Scan(%v) got %v, want %v", src, got, tt.want)
+				}
+			}
+			if err = tt.scanner.Scan(int64(1)); !errors.Is(err, ErrUnsupportedScanType) {
+				t.Errorf("expected %v, got %v", ErrUnsupportedScanType, err)
+			}
+			if err = tt.scanner.Scan(nil); !errors.Is(err, ErrUnsupportedScanType) {
+				t.Errorf("expected %v, got %v", ErrUnsupportedScanType, err)
+			}
+		})
+	}
+
+	// The Influx64 string of this ID only has digits, Influx64ID reads it as Influx64 where ID reads a decimal
+	var influx Influx64ID
+	if err := influx.Scan("12345678901"); err != nil || ID(influx) != IDFromInflux64String("12345678901") {
+		t.Errorf("got %v, %v, want %v", uint64(influx), err, uint64(IDFromInflux64String("12345678901")))
+	}
+}
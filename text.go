@@ -0,0 +1,138 @@
+package snowflake
+
+import "github.com/crosscode-nl/snowflake/internal/codecs/base64"
+
+// MarshalText implements encoding.TextMarshaler using the Influx64 encoding
+func (id ID) MarshalText() ([]byte, error) {
+	return id.AppendInflux64(make([]byte, 0, 11)), nil
+}
+
+// AppendText appends the Influx64 encoding of the ID to b and returns the extended buffer
+func (id ID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendInflux64(b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the Influx64 encoding
+func (id *ID) UnmarshalText(text []byte) error {
+	v, err := ParseInflux64Bytes(text)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// Influx64ID is a snowflake ID that is marshalled as an Influx64 string, also in JSON and database columns
+type Influx64ID ID
+
+// String returns an Influx64 string of the snowflake ID
+func (id Influx64ID) String() string {
+	return ID(id).String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the Influx64 encoding
+func (id Influx64ID) MarshalText() ([]byte, error) {
+	return ID(id).AppendInflux64(make([]byte, 0, 11)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the Influx64 encoding
+func (id *Influx64ID) UnmarshalText(text []byte) error {
+	v, err := ParseInflux64Bytes(text)
+	if err != nil {
+		return err
+	}
+	*id = Influx64ID(v)
+	return nil
+}
+
+// LowerHexID is a snowflake ID that is marshalled as a lower case hex string
+type LowerHexID ID
+
+// String returns a lower case hex string of the snowflake ID
+func (id LowerHexID) String() string {
+	return ID(id).LowerHexString()
+}
+
+// MarshalText implements encoding.TextMarshaler using the lower case hex encoding
+func (id LowerHexID) MarshalText() ([]byte, error) {
+	return ID(id).AppendLowerHex(make([]byte, 0, 16)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the lower case hex encoding
+func (id *LowerHexID) UnmarshalText(text []byte) error {
+	v, err := ParseLowerHexBytes(text)
+	if err != nil {
+		return err
+	}
+	*id = LowerHexID(v)
+	return nil
+}
+
+// UpperHexID is a snowflake ID that is marshalled as an upper case hex string
+type UpperHexID ID
+
+// String returns an upper case hex string of the snowflake ID
+func (id UpperHexID) String() string {
+	return ID(id).UpperHexString()
+}
+
+// MarshalText implements encoding.TextMarshaler using the upper case hex encoding
+func (id UpperHexID) MarshalText() ([]byte, error) {
+	return ID(id).AppendUpperHex(make([]byte, 0, 16)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the upper case hex encoding
+func (id *UpperHexID) UnmarshalText(text []byte) error {
+	v, err := ParseUpperHexBytes(text)
+	if err != nil {
+		return err
+	}
+	*id = UpperHexID(v)
+	return nil
+}
+
+// Base64ID is a snowflake ID that is marshalled as a base64 string
+type Base64ID ID
+
+// String returns a base64 string of the snowflake ID
+func (id Base64ID) String() string {
+	return ID(id).Base64String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the base64 encoding
+func (id Base64ID) MarshalText() ([]byte, error) {
+	return ID(id).AppendBase64(make([]byte, 0, 11)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the base64 encoding
+func (id *Base64ID) UnmarshalText(text []byte) error {
+	v, err := ParseBase64Bytes(text)
+	if err != nil {
+		return err
+	}
+	*id = Base64ID(v)
+	return nil
+}
+
+// URLBase64ID is a snowflake ID that is marshalled as a base64 string with the url alphabet
+type URLBase64ID ID
+
+// String returns a base64 string with the url alphabet of the snowflake ID
+func (id URLBase64ID) String() string {
+	return ID(id).Base64StringCustom(base64.UrlAlphabet)
+}
+
+// MarshalText implements encoding.TextMarshaler using the base64 encoding with the url alphabet
+func (id URLBase64ID) MarshalText() ([]byte, error) {
+	return ID(id).AppendBase64Custom(make([]byte, 0, 11), base64.UrlAlphabet), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the base64 encoding with the url alphabet
+func (id *URLBase64ID) UnmarshalText(text []byte) error {
+	v, err := parseURLBase64(text)
+	if err != nil {
+		return err
+	}
+	*id = URLBase64ID(v)
+	return nil
+}
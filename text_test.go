@@ -0,0 +1,110 @@
+package snowflake
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestID_MarshalText tests the text marshalling of the ID and its wrapper types
+func TestID_MarshalText(t *testing.T) {
+	const id = ID(0xA000B00F0A023452)
+	tests := []struct {
+		name      string
+		marshaler encoding.TextMarshaler
+		want      string
+	}{
+		{name: "ID", marshaler: id, want: "A00h0xA0ZHI"},
+		{name: "Influx64ID", marshaler: Influx64ID(id), want: "A00h0xA0ZHI"},
+		{name: "LowerHexID", marshaler: LowerHexID(id), want: "a000b00f0a023452"},
+		{name: "UpperHexID", marshaler: UpperHexID(id), want: "A000B00F0A023452"},
+		{name: "Base64ID", marshaler: Base64ID(id), want: "UjQCCg+wAKA"},
+		{name: "URLBase64ID", marshaler: URLBase64ID(id), want: "UjQCCg-wAKA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.marshaler.MarshalText()
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %v, want %v", string(got), tt.want)
+			}
+			if s := tt.marshaler.(fmt.Stringer).String(); s != tt.want {
+				t.Errorf("String() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+// TestID_UnmarshalText tests the text unmarshalling of the ID and its wrapper types
+func TestID_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name        string
+		unmarshaler encoding.TextUnmarshaler
+		input       string
+		err         error
+	}{
+		{name: "ID", unmarshaler: new(ID), input: "A00h0xA0ZHI"},
+		{name: "ID invalid", unmarshaler: new(ID), input: "A00h0xA0Z+I", err: ErrInvalidCharacter},
+		{name: "Influx64ID", unmarshaler: new(Influx64ID), input: "A00h0xA0ZHI"},
+		{name: "Influx64ID invalid", unmarshaler: new(Influx64ID), input: "A00h0xA0ZH", err: ErrInvalidLength},
+		{name: "LowerHexID", unmarshaler: new(LowerHexID), input: "a000b00f0a023452"},
+		{name: "LowerHexID invalid", unmarshaler: new(LowerHexID), input: "A000B00F0A023452", err: ErrInvalidCharacter},
+		{name: "UpperHexID", unmarshaler: new(UpperHexID), input: "A000B00F0A023452"},
+		{name: "UpperHexID invalid", unmarshaler: new(UpperHexID), input: "A000B00F0A02345", err: ErrInvalidLength},
+		{name: "Base64ID", unmarshaler: new(Base64ID), input: "UjQCCg+wAKA"},
+		{name: "Base64ID invalid", unmarshaler: new(Base64ID), input: "UjQCCg-wAKA", err: ErrInvalidCharacter},
+		{name: "URLBase64ID", unmarshaler: new(URLBase64ID), input: "UjQCCg-wAKA"},
+		{name: "URLBase64ID invalid", unmarshaler: new(URLBase64ID), input: "UjQCCg+wAKA", err: ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.unmarshaler.UnmarshalText([]byte(tt.input))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			got, _ := tt.unmarshaler.(encoding.TextMarshaler).MarshalText()
+			if string(got) != tt.input {
+				t.Errorf("round trip got %v, want %v", string(got), tt.input)
+			}
+		})
+	}
+}
+
+// TestID_MarshalText_MapKeyAndAttribute tests the ID as a JSON map key and an XML attribute
+func TestID_MarshalText_MapKeyAndAttribute(t *testing.T) {
+	m := map[ID]string{1: "one"}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(b) != `{"00000000001":"one"}` {
+		t.Errorf("got %s", b)
+	}
+	var m2 map[ID]string
+	if err = json.Unmarshal(b, &m2); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if m2[1] != "one" {
+		t.Errorf("got %v", m2)
+	}
+
+	type element struct {
+		ID    ID         `xml:"id,attr"`
+		Other LowerHexID `xml:"other,attr"`
+	}
+	b, err = xml.Marshal(element{ID: 1, Other: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(b) != `<element id="00000000001" other="0000000000000002"></element>` {
+		t.Errorf("got %s", b)
+	}
+}